@@ -10,14 +10,19 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"reflect"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
 const (
 	microToSeconds  = 1000000 // conversion factor
 	maxTokenTimeout = 36000   // maximum token timeout in seconds
+	uriTransaction  = "transaction"
 )
 
 var defaultConfigOptions = &ConfigOptions{
@@ -38,7 +43,16 @@ type BigIP struct {
 	Transport     *http.Transport
 	ConfigOptions *ConfigOptions
 	loginProvider string
-	startTime     time.Time // token start time
+	startTime     time.Time     // token start time
+	version       *versionCache // TMOS version, cached by TMOSVersion
+	transaction   string        // id of the transaction writes are queued in, set by InTransaction
+}
+
+// versionCache holds the TMOS version of a session once it is known. Copies of a session,
+// such as those returned by InTransaction, share it.
+type versionCache struct {
+	sync.Mutex
+	version string
 }
 
 // APIRequest builds our request before sending it to the server.
@@ -98,6 +112,7 @@ func NewSession(host, user, passwd string, configOptions *ConfigOptions) *BigIP
 			Proxy: http.ProxyFromEnvironment,
 		},
 		ConfigOptions: configOptions,
+		version:       &versionCache{},
 	}
 }
 
@@ -137,6 +152,10 @@ func (b *BigIP) APICall(options *APIRequest) ([]byte, error) {
 		req.SetBasicAuth(b.User, b.Password)
 	}
 
+	if b.transaction != "" && format != "%s/%s" && !strings.HasPrefix(options.URL, uriTransaction) && req.Method != "GET" {
+		req.Header.Set("X-F5-REST-Coordination-Id", b.transaction)
+	}
+
 	// fmt.Println("REQ -- ", options.Method, " ", url, " -- ", options.Body)
 
 	if len(options.ContentType) > 0 {
@@ -265,6 +284,159 @@ func (b *BigIP) getForEntity(e interface{}, path ...string) (error, bool) {
 	return nil, true
 }
 
+// statsEntries is the layout returned by the iControl REST /stats endpoints: a map
+// of self links to the nested counters of each object.
+type statsEntries struct {
+	Entries map[string]struct {
+		NestedStats struct {
+			Entries map[string]StatValue `json:"entries"`
+		} `json:"nestedStats"`
+	} `json:"entries"`
+}
+
+// StatValue is a single counter or status description returned from a /stats endpoint.
+type StatValue struct {
+	Value       int64  `json:"value,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// rawValues is returned by endpoints that wrap the plain text output of a tmsh command.
+type rawValues struct {
+	APIRawValues struct {
+		APIAnonymous string `json:"apiAnonymous"`
+	} `json:"apiRawValues"`
+}
+
+// tmshOptions builds the options query string used to filter and scope tmsh commands.
+// Options are passed as name/value pairs; pairs with an empty value are skipped.
+func tmshOptions(options ...string) string {
+	var opts []string
+	for i := 0; i+1 < len(options); i += 2 {
+		if options[i+1] != "" {
+			opts = append(opts, options[i], options[i+1])
+		}
+	}
+	if len(opts) == 0 {
+		return ""
+	}
+
+	return "?options=" + url.QueryEscape(strings.Join(opts, " "))
+}
+
+// rawRequest sends a request with the given tmsh options and returns the plain text output
+// of the command, for endpoints that do not return structured JSON.
+func (b *BigIP) rawRequest(method string, options []string, path ...string) (string, error) {
+	req := &APIRequest{
+		Method:      method,
+		URL:         b.iControlPath(path) + tmshOptions(options...),
+		ContentType: "application/json",
+	}
+	resp, err := b.APICall(req)
+	if err != nil {
+		return "", err
+	}
+	if len(resp) == 0 {
+		return "", nil
+	}
+
+	var raw rawValues
+	if err := json.Unmarshal(resp, &raw); err != nil {
+		return "", err
+	}
+
+	return raw.APIRawValues.APIAnonymous, nil
+}
+
+// getStats gets the stats of an object and returns its counters keyed by name. If the
+// object does not exist (404) then nil is returned for both values.
+func (b *BigIP) getStats(path ...string) (map[string]StatValue, error) {
+	var stats statsEntries
+	err, ok := b.getForEntity(&stats, append(path, "stats")...)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	for _, e := range stats.Entries {
+		return e.NestedStats.Entries, nil
+	}
+
+	return map[string]StatValue{}, nil
+}
+
+// Transaction is an iControl REST transaction. Changes made through the session returned
+// by InTransaction are queued on the BIG-IP and applied together on commit.
+type Transaction struct {
+	TransID               int64  `json:"transId,omitempty"`
+	State                 string `json:"state,omitempty"`
+	TimeoutSeconds        int    `json:"timeoutSeconds,omitempty"`
+	AsyncExecutionTimeout int    `json:"asyncExecutionTimeout,omitempty"`
+	FailureReason         string `json:"failureReason,omitempty"`
+}
+
+// StartTransaction starts a new transaction. Use InTransaction to make changes in it, then
+// either CommitTransaction or AbortTransaction.
+func (b *BigIP) StartTransaction() (*Transaction, error) {
+	var t Transaction
+	if err := b.transactionCall("post", &t, uriTransaction); err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+// InTransaction returns a copy of the session whose configuration changes are queued in
+// the transaction t instead of applied; reads are not part of the transaction. The
+// original session is not affected, so it can keep being used by other goroutines.
+func (b *BigIP) InTransaction(t *Transaction) *BigIP {
+	tx := *b
+	tx.transaction = strconv.FormatInt(t.TransID, 10)
+
+	return &tx
+}
+
+// CommitTransaction commits a transaction. Either all of its changes are applied or, if
+// any of them fails, none are.
+func (b *BigIP) CommitTransaction(t *Transaction) error {
+	id := strconv.FormatInt(t.TransID, 10)
+	commit := Transaction{State: "VALIDATING"}
+	if err := b.transactionCall("patch", &commit, uriTransaction, id); err != nil {
+		return err
+	}
+	if commit.State == "FAILED" {
+		return fmt.Errorf("transaction %s failed: %s", id, commit.FailureReason)
+	}
+
+	return nil
+}
+
+// AbortTransaction discards a transaction and all of its queued changes.
+func (b *BigIP) AbortTransaction(t *Transaction) error {
+	return b.delete(uriTransaction, strconv.FormatInt(t.TransID, 10))
+}
+
+// transactionCall sends t to the transaction endpoint and decodes the response into it.
+func (b *BigIP) transactionCall(method string, t *Transaction, path ...string) error {
+	body, err := jsonMarshal(t)
+	if err != nil {
+		return err
+	}
+	req := &APIRequest{
+		Method:      method,
+		URL:         b.iControlPath(path),
+		Body:        strings.TrimRight(string(body), "\n"),
+		ContentType: "application/json",
+	}
+	resp, err := b.APICall(req)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(resp, t)
+}
+
 // checkError handles any errors we get from our API requests. It returns either the
 // message of the error, if any, or nil.
 func (b *BigIP) checkError(resp []byte) error {
@@ -287,6 +459,210 @@ func (b *BigIP) checkError(resp []byte) error {
 	return nil
 }
 
+// splitFullPath returns the partition and name of an object given as "name",
+// "/Partition/name" or "~Partition~name". A bare name is in the Common partition.
+func splitFullPath(name string) (string, string) {
+	name = strings.Replace(name, "~", "/", -1)
+	if strings.HasPrefix(name, "/") {
+		parts := strings.Split(name[1:], "/")
+		return parts[0], parts[len(parts)-1]
+	}
+	return "Common", name
+}
+
+// maxEditAttempts is how many times a read-modify-write of an object is retried when the
+// object changes underneath it.
+const maxEditAttempts = 3
+
+// generationEdit reads an object and computes a change to it. It returns the generation
+// the change was computed from and a function that writes the change, or a nil function
+// if there is nothing to change.
+type generationEdit func() (generation int, write func() error, err error)
+
+// editWithGeneration runs a read-modify-write of an object. generation and write are the
+// result of an earlier call to edit, or zero and nil to start with a fresh read. Before a
+// change is written, the object is read again and the write is only sent when its
+// generation still matches the generation the change was computed from; otherwise the
+// change is recomputed from the newer read.
+//
+// iControl REST does not enforce the generation of a write, so this only narrows the
+// window for lost updates to the time between the last read and the write; it does not
+// close it.
+func (b *BigIP) editWithGeneration(what string, edit generationEdit, generation int, write func() error) error {
+	for attempt := 0; attempt < maxEditAttempts; attempt++ {
+		latest, latestWrite, err := edit()
+		if err != nil {
+			return err
+		}
+		if latestWrite == nil {
+			return nil
+		}
+		if write != nil && latest == generation {
+			return write()
+		}
+		generation, write = latest, latestWrite
+	}
+
+	return fmt.Errorf("%s kept changing, gave up after %d attempts", what, maxEditAttempts)
+}
+
+// tmshRecord is an entry of the "records" collection of an object that holds a single
+// value, such as a data group record or a forwarding database entry.
+type tmshRecord struct {
+	Name  string
+	Value string
+}
+
+// recordEdit computes the records to add, modify and remove given the current records of
+// an object, keyed by name, and updates current to match.
+type recordEdit func(current map[string]string) (add, modify []tmshRecord, remove []string)
+
+// recordCollection describes how to read and write the "records" collection of an object.
+type recordCollection struct {
+	what string
+
+	// read returns the generation and records of the object, or an error if it does
+	// not exist.
+	read func() (int, []tmshRecord, error)
+
+	// format renders a record, and quote a record name, as tmsh expects them in the
+	// records option.
+	format func(r tmshRecord) string
+	quote  func(name string) string
+
+	// patch modifies the object with a tmsh option, and replace writes its records in full.
+	patch   func(option string) error
+	replace func(generation int, records []tmshRecord) error
+}
+
+// editRecords applies the minimal change computed by edit to the records of an object
+// using the tmsh "records add/modify/delete" forms. If the BIG-IP does not support those,
+// the records are replaced in full instead, see editWithGeneration.
+func (b *BigIP) editRecords(c *recordCollection, edit recordEdit) error {
+	var add, modify []tmshRecord
+	var remove []string
+	replace := func() (int, func() error, error) {
+		generation, existing, err := c.read()
+		if err != nil {
+			return 0, nil, err
+		}
+
+		current := make(map[string]string, len(existing))
+		for _, r := range existing {
+			current[r.Name] = r.Value
+		}
+		add, modify, remove = edit(current)
+		if len(add) == 0 && len(modify) == 0 && len(remove) == 0 {
+			return generation, nil, nil
+		}
+
+		records := mergeRecords(existing, current)
+		return generation, func() error {
+			return c.replace(generation, records)
+		}, nil
+	}
+
+	generation, write, err := replace()
+	if err != nil || write == nil {
+		return err
+	}
+
+	supported, err := c.patchRecords(add, modify, remove)
+	if supported {
+		return err
+	}
+
+	return b.editWithGeneration(c.what, replace, generation, write)
+}
+
+// patchRecords sends each non-empty record edit as a tmsh modify option. Each option is
+// a separate request, so the edits are not applied atomically: if one fails, the ones
+// before it stay applied. It returns false if the BIG-IP does not support the records
+// option, in which case nothing was changed.
+func (c *recordCollection) patchRecords(add, modify []tmshRecord, remove []string) (bool, error) {
+	list := func(items []string) string {
+		return "{ " + strings.Join(items, " ") + " }"
+	}
+	formatAll := func(records []tmshRecord) string {
+		items := make([]string, len(records))
+		for i, r := range records {
+			items[i] = c.format(r)
+		}
+		return list(items)
+	}
+
+	var options []string
+	if len(add) > 0 {
+		options = append(options, "add "+formatAll(add))
+	}
+	if len(modify) > 0 {
+		options = append(options, "modify "+formatAll(modify))
+	}
+	if len(remove) > 0 {
+		names := make([]string, len(remove))
+		for i, n := range remove {
+			names[i] = c.quote(n)
+		}
+		options = append(options, "delete "+list(names))
+	}
+
+	for i, o := range options {
+		if err := c.patch(tmshOptions("records", o)); err != nil {
+			return i > 0 || !isUnsupportedOptionError(err), err
+		}
+	}
+
+	return true, nil
+}
+
+// mergeRecords returns the records in current, keeping the order of the existing records
+// and appending new ones at the end, sorted by name.
+func mergeRecords(existing []tmshRecord, current map[string]string) []tmshRecord {
+	records := []tmshRecord{}
+	seen := map[string]bool{}
+	for _, r := range existing {
+		if value, ok := current[r.Name]; ok {
+			records = append(records, tmshRecord{Name: r.Name, Value: value})
+			seen[r.Name] = true
+		}
+	}
+	var added []string
+	for n := range current {
+		if !seen[n] {
+			added = append(added, n)
+		}
+	}
+	sort.Strings(added)
+	for _, n := range added {
+		records = append(records, tmshRecord{Name: n, Value: current[n]})
+	}
+
+	return records
+}
+
+// isUnsupportedOptionError reports whether err is the BIG-IP rejecting a tmsh option
+// passed in the options query parameter, which older versions do not accept for every
+// command.
+func isUnsupportedOptionError(err error) bool {
+	return err != nil && strings.Contains(strings.ToLower(err.Error()), "invalid option")
+}
+
+// withCleanupErrors returns err annotated with the errors of any cleanup steps that failed
+// after it, so the caller knows objects may have been left behind.
+func withCleanupErrors(err error, cleanupErrs ...error) error {
+	var msgs []string
+	for _, e := range cleanupErrs {
+		if e != nil {
+			msgs = append(msgs, e.Error())
+		}
+	}
+	if len(msgs) == 0 {
+		return err
+	}
+
+	return fmt.Errorf("%s (cleanup failed: %s)", err, strings.Join(msgs, "; "))
+}
+
 // jsonMarshal specifies an encoder with 'SetEscapeHTML' set to 'false' so that <, >, and & are not escaped. https://golang.org/pkg/encoding/json/#Marshal
 // https://stackoverflow.com/questions/28595664/how-to-stop-json-marshal-from-escaping-and
 func jsonMarshal(t interface{}) ([]byte, error) {
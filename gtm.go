@@ -2,6 +2,10 @@ package bigip
 
 import (
 	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
 )
 
 // GTM Documentation
@@ -22,25 +26,27 @@ type GTMWideIPs struct {
 // Type is what determine the type of record the WideIp is for in the docs, however that is NOT returned by the API
 // Instead you have to query the Type by the uri   wideip/a  wideip/cname  that = type
 type GTMWideIP struct {
-	Name                              string   `json:"name,omitempty"`
-	Partition                         string   `json:"partition,omitempty"`
-	FullPath                          string   `json:"fullPath,omitempty"`
-	Generation                        int      `json:"generation,omitempty"`
-	AppService                        string   `json:"appService,omitempty"`
-	Description                       string   `json:"description,omitempty"`
-	Disabled                          bool     `json:"disabled,omitempty"`
-	Enabled                           bool     `json:"enabled,omitempty"`
-	FailureRcode                      string   `json:"failureRcode,omitempty"`
-	FailureRcodeResponse              string   `json:"failureRcodeResponse,omitempty"`
-	FailureRcodeTTL                   int      `json:"failureRcodeTtl,omitempty"`
-	LastResortPool                    string   `json:"lastResortPool,omitempty"`
-	LoadBalancingDecisionLogVerbosity []string `json:"loadBalancingDecisionLogVerbosity,omitempty"`
-	MinimalResponse                   string   `json:"minimalResponse,omitempty"`
-	PersistCidrIpv4                   int      `json:"persistCidrIpv4,omitempty"`
-	PersistCidrIpv6                   int      `json:"persistCidrIpv6,omitempty"`
-	Persistence                       string   `json:"persistence,omitempty"`
-	PoolLbMode                        string   `json:"poolLbMode,omitempty"`
-	TTLPersistence                    int      `json:"ttlPersistence,omitempty"`
+	Name                              string    `json:"name,omitempty"`
+	Partition                         string    `json:"partition,omitempty"`
+	FullPath                          string    `json:"fullPath,omitempty"`
+	Generation                        int       `json:"generation,omitempty"`
+	AppService                        string    `json:"appService,omitempty"`
+	Description                       string    `json:"description,omitempty"`
+	Disabled                          bool      `json:"disabled,omitempty"`
+	Enabled                           bool      `json:"enabled,omitempty"`
+	FailureRcode                      string    `json:"failureRcode,omitempty"`
+	FailureRcodeResponse              string    `json:"failureRcodeResponse,omitempty"`
+	FailureRcodeTTL                   int       `json:"failureRcodeTtl,omitempty"`
+	LastResortPool                    string    `json:"lastResortPool,omitempty"`
+	LoadBalancingDecisionLogVerbosity []string  `json:"loadBalancingDecisionLogVerbosity,omitempty"`
+	MinimalResponse                   string    `json:"minimalResponse,omitempty"`
+	PersistCidrIpv4                   int       `json:"persistCidrIpv4,omitempty"`
+	PersistCidrIpv6                   int       `json:"persistCidrIpv6,omitempty"`
+	Persistence                       string    `json:"persistence,omitempty"`
+	PoolLbMode                        string    `json:"poolLbMode,omitempty"`
+	TTLPersistence                    int       `json:"ttlPersistence,omitempty"`
+	Aliases                           *[]string `json:"aliases,omitempty"`
+	Rules                             *[]string `json:"rules,omitempty"`
 
 	// Not in the spec, but returned by the API
 	// Setting this field atomically updates all members.
@@ -99,6 +105,207 @@ func (b *BigIP) ModifyGTMWideIP(fullPath string, config *GTMWideIP, recordType G
 	return b.put(config, uriGtm, uriWideIp, string(recordType), fullPath)
 }
 
+// editWideIP patches a wide IP with the change edit computes from its current config,
+// see editWithGeneration.
+func (b *BigIP) editWideIP(name string, recordType GTMType, edit func(w *GTMWideIP) (*GTMWideIP, error)) error {
+	return b.editWithGeneration("wide IP "+name, func() (int, func() error, error) {
+		w, err := b.GetGTMWideIP(name, recordType)
+		if err != nil {
+			return 0, nil, err
+		}
+		if w == nil {
+			return 0, nil, fmt.Errorf("wide IP %s does not exist", name)
+		}
+		config, err := edit(w)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		config.Generation = w.Generation
+		return w.Generation, func() error {
+			return b.patch(config, uriGtm, uriWideIp, string(recordType), name)
+		}, nil
+	}, 0, nil)
+}
+
+// wideIPPoolIndex returns the index of pool in pools, or -1.
+func wideIPPoolIndex(pools []GTMWideIPPool, pool string) int {
+	partition, name := splitFullPath(pool)
+	for i, p := range pools {
+		if p.Name == name && (p.Partition == partition || p.Partition == "" && partition == "Common") {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// renumberWideIPPools sets the order of pools to their position.
+func renumberWideIPPools(pools []GTMWideIPPool) []GTMWideIPPool {
+	for i := range pools {
+		pools[i].Order = i
+	}
+
+	return pools
+}
+
+// AddPoolToWideIP adds a pool to a wide IP, after its existing pools. A ratio of 0 uses
+// the BIG-IP default.
+func (b *BigIP) AddPoolToWideIP(wideIP string, recordType GTMType, pool string, ratio int) error {
+	return b.editWideIP(wideIP, recordType, func(w *GTMWideIP) (*GTMWideIP, error) {
+		var pools []GTMWideIPPool
+		if w.Pools != nil {
+			pools = *w.Pools
+		}
+		if wideIPPoolIndex(pools, pool) >= 0 {
+			return nil, fmt.Errorf("pool %s is already a member of wide IP %s", pool, wideIP)
+		}
+
+		partition, name := splitFullPath(pool)
+		pools = renumberWideIPPools(append(pools, GTMWideIPPool{Name: name, Partition: partition, Ratio: ratio}))
+		return &GTMWideIP{Pools: &pools}, nil
+	})
+}
+
+// RemovePoolFromWideIP removes a pool from a wide IP.
+func (b *BigIP) RemovePoolFromWideIP(wideIP string, recordType GTMType, pool string) error {
+	return b.editWideIP(wideIP, recordType, func(w *GTMWideIP) (*GTMWideIP, error) {
+		var pools []GTMWideIPPool
+		if w.Pools != nil {
+			pools = *w.Pools
+		}
+		i := wideIPPoolIndex(pools, pool)
+		if i < 0 {
+			return nil, fmt.Errorf("pool %s is not a member of wide IP %s", pool, wideIP)
+		}
+
+		pools = renumberWideIPPools(append(pools[:i:i], pools[i+1:]...))
+		return &GTMWideIP{Pools: &pools}, nil
+	})
+}
+
+// SetWideIPPoolOrder orders the pools of a wide IP. pools must list every pool of the
+// wide IP exactly once.
+func (b *BigIP) SetWideIPPoolOrder(wideIP string, recordType GTMType, pools []string) error {
+	return b.editWideIP(wideIP, recordType, func(w *GTMWideIP) (*GTMWideIP, error) {
+		var current []GTMWideIPPool
+		if w.Pools != nil {
+			current = *w.Pools
+		}
+		if len(pools) != len(current) {
+			return nil, fmt.Errorf("wide IP %s has %d pools, got %d", wideIP, len(current), len(pools))
+		}
+
+		ordered := make([]GTMWideIPPool, 0, len(pools))
+		used := map[int]bool{}
+		for _, pool := range pools {
+			i := wideIPPoolIndex(current, pool)
+			if i < 0 {
+				return nil, fmt.Errorf("pool %s is not a member of wide IP %s", pool, wideIP)
+			}
+			if used[i] {
+				return nil, fmt.Errorf("pool %s is listed more than once", pool)
+			}
+			used[i] = true
+			ordered = append(ordered, current[i])
+		}
+		ordered = renumberWideIPPools(ordered)
+		return &GTMWideIP{Pools: &ordered}, nil
+	})
+}
+
+// SetWideIPPoolRatio sets the ratio of a pool of a wide IP, used when the wide IP pool
+// load balancing mode is "ratio".
+func (b *BigIP) SetWideIPPoolRatio(wideIP string, recordType GTMType, pool string, ratio int) error {
+	return b.editWideIP(wideIP, recordType, func(w *GTMWideIP) (*GTMWideIP, error) {
+		var pools []GTMWideIPPool
+		if w.Pools != nil {
+			pools = *w.Pools
+		}
+		i := wideIPPoolIndex(pools, pool)
+		if i < 0 {
+			return nil, fmt.Errorf("pool %s is not a member of wide IP %s", pool, wideIP)
+		}
+
+		pools[i].Ratio = ratio
+		return &GTMWideIP{Pools: &pools}, nil
+	})
+}
+
+// AddWideIPAlias adds an alias, such as "*.example.com", to a wide IP.
+func (b *BigIP) AddWideIPAlias(wideIP string, recordType GTMType, alias string) error {
+	return b.editWideIP(wideIP, recordType, func(w *GTMWideIP) (*GTMWideIP, error) {
+		aliases, err := addWideIPListItem(w.Aliases, alias)
+		if err != nil {
+			return nil, err
+		}
+		return &GTMWideIP{Aliases: aliases}, nil
+	})
+}
+
+// RemoveWideIPAlias removes an alias from a wide IP.
+func (b *BigIP) RemoveWideIPAlias(wideIP string, recordType GTMType, alias string) error {
+	return b.editWideIP(wideIP, recordType, func(w *GTMWideIP) (*GTMWideIP, error) {
+		aliases, err := removeWideIPListItem(w.Aliases, alias)
+		if err != nil {
+			return nil, err
+		}
+		return &GTMWideIP{Aliases: aliases}, nil
+	})
+}
+
+// AddWideIPIRule attaches an iRule to a wide IP, after its existing iRules.
+func (b *BigIP) AddWideIPIRule(wideIP string, recordType GTMType, rule string) error {
+	return b.editWideIP(wideIP, recordType, func(w *GTMWideIP) (*GTMWideIP, error) {
+		rules, err := addWideIPListItem(w.Rules, rule)
+		if err != nil {
+			return nil, err
+		}
+		return &GTMWideIP{Rules: rules}, nil
+	})
+}
+
+// RemoveWideIPIRule detaches an iRule from a wide IP.
+func (b *BigIP) RemoveWideIPIRule(wideIP string, recordType GTMType, rule string) error {
+	return b.editWideIP(wideIP, recordType, func(w *GTMWideIP) (*GTMWideIP, error) {
+		rules, err := removeWideIPListItem(w.Rules, rule)
+		if err != nil {
+			return nil, err
+		}
+		return &GTMWideIP{Rules: rules}, nil
+	})
+}
+
+func addWideIPListItem(list *[]string, item string) (*[]string, error) {
+	var items []string
+	if list != nil {
+		items = *list
+	}
+	for _, i := range items {
+		if i == item {
+			return nil, fmt.Errorf("%s is already set", item)
+		}
+	}
+
+	items = append(items, item)
+	return &items, nil
+}
+
+func removeWideIPListItem(list *[]string, item string) (*[]string, error) {
+	var items []string
+	if list != nil {
+		items = *list
+	}
+	for i, v := range items {
+		if v == item {
+			items = append(items[:i:i], items[i+1:]...)
+			return &items, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%s is not set", item)
+}
+
 // ********************************************************************************************************************
 // ********************************************                     ***************************************************
 // ********************************************   GTM Pool Common   ***************************************************
@@ -382,118 +589,1107 @@ func (b *BigIP) GetGTMCNamePoolMember(fullPathToAPool, poolMemberFullPath string
 	return &w, nil
 }
 
-/*
-
-These are here for later use -- so no one has to do this painful work!!!
+// ********************************************************************************************************************
+// ********************************************                      **************************************************
+// ********************************************   GTM Pool Generic   **************************************************
+// ********************************************                      **************************************************
+// ********************************************************************************************************************
 
+// GTMPools contains a list of every gtm/pool of a record type on the BIG-IP system.
+type GTMPools struct {
+	GTMPools []GTMPool `json:"items"`
+}
 
+// GTMPool contains information about each gtm/pool, regardless of type: A, AAAA, CNAME, MX,
+// NAPTR or SRV. Fields that do not apply to a record type are omitted by the API.
+type GTMPool struct {
+	Name                      string          `json:"name,omitempty"`
+	Partition                 string          `json:"partition,omitempty"`
+	FullPath                  string          `json:"fullPath,omitempty"`
+	Generation                int             `json:"generation,omitempty"`
+	AppService                string          `json:"appService,omitempty"`
+	Description               string          `json:"description,omitempty"`
+	Disabled                  bool            `json:"disabled,omitempty"`
+	DynamicRatio              string          `json:"dynamicRatio,omitempty"`
+	Enabled                   bool            `json:"enabled,omitempty"`
+	FallbackIP                string          `json:"fallbackIp,omitempty"`
+	FallbackMode              string          `json:"fallbackMode,omitempty"`
+	LimitMaxBps               uint64          `json:"limitMaxBps,omitempty"`
+	LimitMaxBpsStatus         string          `json:"limitMaxBpsStatus,omitempty"`
+	LimitMaxConnections       uint64          `json:"limitMaxConnections,omitempty"`
+	LimitMaxConnectionsStatus string          `json:"limitMaxConnectionsStatus,omitempty"`
+	LimitMaxPps               uint64          `json:"limitMaxPps,omitempty"`
+	LimitMaxPpsStatus         string          `json:"limitMaxPpsStatus,omitempty"`
+	LoadBalancingMode         string          `json:"loadBalancingMode,omitempty"`
+	ManualResume              string          `json:"manualResume,omitempty"`
+	MaxAnswersReturned        int             `json:"maxAnswersReturned,omitempty"`
+	Monitor                   string          `json:"monitor,omitempty"`
+	TmPartition               string          `json:"tmPartition,omitempty"`
+	QosHitRatio               int             `json:"qosHitRatio,omitempty"`
+	QosHops                   int             `json:"qosHops,omitempty"`
+	QosKilobytesSecond        int             `json:"qosKilobytesSecond,omitempty"`
+	QosLcs                    int             `json:"qosLcs,omitempty"`
+	QosPacketRate             int             `json:"qosPacketRate,omitempty"`
+	QosRtt                    int             `json:"qosRtt,omitempty"`
+	QosTopology               int             `json:"qosTopology,omitempty"`
+	QosVsCapacity             int             `json:"qosVsCapacity,omitempty"`
+	QosVsScore                int             `json:"qosVsScore,omitempty"`
+	TTL                       int             `json:"ttl,omitempty"`
+	VerifyMemberAvailability  string          `json:"verifyMemberAvailability,omitempty"`
+	Members                   []GTMPoolMember `json:"members,omitempty"`
+	MembersReference          *struct {
+		Link            string `json:"link,omitempty"`
+		IsSubcollection bool   `json:"isSubcollection,omitempty"`
+	} `json:"membersReference,omitempty"`
+}
 
-// GTMAAAAPools contains a list of every gtm/pool/aaaa on the BIG-IP system.
-type GTMAAAAPools struct {
-	GTMAAAAPools []GTMAAAAPool `json:"items"`
+// GTMPoolMembers contains a list of every member of a gtm/pool on the BIG-IP system.
+type GTMPoolMembers struct {
+	GTMPoolMembers []GTMPoolMember `json:"items"`
 }
 
-// GTMAAAAPool contains information about each gtm/pool/aaaa
-type GTMAAAAPool struct {
+// GTMPoolMember contains information about each gtm/pool member, regardless of type.
+//
+// Members of A and AAAA pools are virtual servers, named "<server>:<virtual server>" (e.g.
+// "/Common/someltm:/Common/app_80_vs"). Members of CNAME pools are wide IPs or static
+// targets, and members of MX, NAPTR and SRV pools are wide IPs.
+type GTMPoolMember struct {
 	Name                      string `json:"name,omitempty"`
 	Partition                 string `json:"partition,omitempty"`
+	SubPath                   string `json:"subPath,omitempty"`
 	FullPath                  string `json:"fullPath,omitempty"`
 	Generation                int    `json:"generation,omitempty"`
 	AppService                string `json:"appService,omitempty"`
 	Description               string `json:"description,omitempty"`
 	Disabled                  bool   `json:"disabled,omitempty"`
-	DynamicRatio              string `json:"dynamicRatio,omitempty"`
 	Enabled                   bool   `json:"enabled,omitempty"`
-	FallbackIP                string `json:"fallbackIp,omitempty"`
-	FallbackMode              string `json:"fallbackMode,omitempty"`
+	Flags                     string `json:"flags,omitempty"`
 	LimitMaxBps               uint64 `json:"limitMaxBps,omitempty"`
 	LimitMaxBpsStatus         string `json:"limitMaxBpsStatus,omitempty"`
 	LimitMaxConnections       uint64 `json:"limitMaxConnections,omitempty"`
 	LimitMaxConnectionsStatus string `json:"limitMaxConnectionsStatus,omitempty"`
 	LimitMaxPps               uint64 `json:"limitMaxPps,omitempty"`
 	LimitMaxPpsStatus         string `json:"limitMaxPpsStatus,omitempty"`
-	LoadBalancingMode         string `json:"loadBalancingMode,omitempty"`
-	ManualResume              string `json:"manualResume,omitempty"`
-	MaxAnswersReturned        int    `json:"maxAnswersReturned,omitempty"`
+	MemberOrder               int    `json:"memberOrder,omitempty"`
 	Monitor                   string `json:"monitor,omitempty"`
-	TmPartition               string `json:"tmPartition,omitempty"`
-	QosHitRatio               int    `json:"qosHitRatio,omitempty"`
-	QosHops                   int    `json:"qosHops,omitempty"`
-	QosKilobytesSecond        int    `json:"qosKilobytesSecond,omitempty"`
-	QosLcs                    int    `json:"qosLcs,omitempty"`
-	QosPacketRate             int    `json:"qosPacketRate,omitempty"`
-	QosRtt                    int    `json:"qosRtt,omitempty"`
-	QosTopology               int    `json:"qosTopology,omitempty"`
-	QosVsCapacity             int    `json:"qosVsCapacity,omitempty"`
-	QosVsScore                int    `json:"qosVsScore,omitempty"`
-	TTL                       int    `json:"ttl,omitempty"`
-	VerifyMemberAvailability  string `json:"verifyMemberAvailability,omitempty"`
+	Ratio                     int    `json:"ratio,omitempty"`
+	Service                   string `json:"service,omitempty"`
+	StaticTarget              string `json:"staticTarget,omitempty"`
 }
 
-// GTMMXPools contains a list of every gtm/pool/mx on the BIG-IP system.
-type GTMMXPools struct {
-	GTMMXPools []GTMMXPool `json:"items"`
+// GetGTMPools returns a list of all pools of the given record type.
+func (b *BigIP) GetGTMPools(recordType GTMType) (*GTMPools, error) {
+	var p GTMPools
+	err, _ := b.getForEntity(&p, uriGtm, uriPool, string(recordType))
+	if err != nil {
+		return nil, err
+	}
+
+	return &p, nil
 }
 
-// GTMMXPool contains information about each gtm/pool/mx
-type GTMMXPool struct {
-	Name                     string `json:"name,omitempty"`
-	Partition                string `json:"partition,omitempty"`
-	FullPath                 string `json:"fullPath,omitempty"`
-	Generation               int    `json:"generation,omitempty"`
-	AppService               string `json:"appService,omitempty"`
-	Description              string `json:"description,omitempty"`
-	Disabled                 bool   `json:"disabled,omitempty"`
-	DynamicRatio             string `json:"dynamicRatio,omitempty"`
-	Enabled                  bool   `json:"enabled,omitempty"`
-	FallbackMode             string `json:"fallbackMode,omitempty"`
-	LoadBalancingMode        string `json:"loadBalancingMode,omitempty"`
-	ManualResume             string `json:"manualResume,omitempty"`
-	TmPartition              string `json:"tmPartition,omitempty"`
-	QosHitRatio              int    `json:"qosHitRatio,omitempty"`
-	QosHops                  int    `json:"qosHops,omitempty"`
-	QosKilobytesSecond       int    `json:"qosKilobytesSecond,omitempty"`
-	QosLcs                   int    `json:"qosLcs,omitempty"`
-	QosPacketRate            int    `json:"qosPacketRate,omitempty"`
-	QosRtt                   int    `json:"qosRtt,omitempty"`
-	QosTopology              int    `json:"qosTopology,omitempty"`
-	QosVsCapacity            int    `json:"qosVsCapacity,omitempty"`
-	QosVsScore               int    `json:"qosVsScore,omitempty"`
-	TTL                      int    `json:"ttl,omitempty"`
-	VerifyMemberAvailability string `json:"verifyMemberAvailability,omitempty"`
+// GetGTMPool gets a pool of the given record type by name. Returns nil if the pool does not exist.
+func (b *BigIP) GetGTMPool(name string, recordType GTMType) (*GTMPool, error) {
+	var p GTMPool
+	err, ok := b.getForEntity(&p, uriGtm, uriPool, string(recordType), name)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	return &p, nil
 }
 
-// GTMSrvPools contains a list of every gtm/pool/srv on the BIG-IP system.
-type GTMSrvPools struct {
-	GTMSrvPools []GTMSrvPool `json:"items"`
+// AddGTMPool adds a pool of the given record type by config to the BIG-IP system.
+func (b *BigIP) AddGTMPool(config *GTMPool, recordType GTMType) error {
+	return b.post(config, uriGtm, uriPool, string(recordType))
 }
 
-// GTMSrvPool contains information about each gtm/pool/srv
-type GTMSrvPool struct {
-	Name                     string `json:"name,omitempty"`
-	Partition                string `json:"partition,omitempty"`
-	FullPath                 string `json:"fullPath,omitempty"`
-	Generation               int    `json:"generation,omitempty"`
-	AppService               string `json:"appService,omitempty"`
-	Description              string `json:"description,omitempty"`
-	Disabled                 bool   `json:"disabled,omitempty"`
-	DynamicRatio             string `json:"dynamicRatio,omitempty"`
-	Enabled                  bool   `json:"enabled,omitempty"`
-	FallbackMode             string `json:"fallbackMode,omitempty"`
-	LoadBalancingMode        string `json:"loadBalancingMode,omitempty"`
-	ManualResume             string `json:"manualResume,omitempty"`
-	TmPartition              string `json:"tmPartition,omitempty"`
-	QosHitRatio              int    `json:"qosHitRatio,omitempty"`
-	QosHops                  int    `json:"qosHops,omitempty"`
-	QosKilobytesSecond       int    `json:"qosKilobytesSecond,omitempty"`
-	QosLcs                   int    `json:"qosLcs,omitempty"`
-	QosPacketRate            int    `json:"qosPacketRate,omitempty"`
-	QosRtt                   int    `json:"qosRtt,omitempty"`
-	QosTopology              int    `json:"qosTopology,omitempty"`
-	QosVsCapacity            int    `json:"qosVsCapacity,omitempty"`
-	QosVsScore               int    `json:"qosVsScore,omitempty"`
-	TTL                      int    `json:"ttl,omitempty"`
-	VerifyMemberAvailability string `json:"verifyMemberAvailability,omitempty"`
+// ModifyGTMPool updates a pool of the given record type by config.
+func (b *BigIP) ModifyGTMPool(fullPath string, config *GTMPool, recordType GTMType) error {
+	return b.put(config, uriGtm, uriPool, string(recordType), fullPath)
+}
+
+// GetGTMPoolMembers returns a list of all members of a pool of the given record type.
+func (b *BigIP) GetGTMPoolMembers(fullPathToPool string, recordType GTMType) (*GTMPoolMembers, error) {
+	var m GTMPoolMembers
+	err, _ := b.getForEntity(&m, uriGtm, uriPool, string(recordType), fullPathToPool, uriPoolMembers)
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
 }
 
-*/
+// GetGTMPoolMember gets a member of a pool of the given record type by name. Returns nil
+// if the member does not exist.
+func (b *BigIP) GetGTMPoolMember(fullPathToPool, member string, recordType GTMType) (*GTMPoolMember, error) {
+	var m GTMPoolMember
+	err, ok := b.getForEntity(&m, uriGtm, uriPool, string(recordType), fullPathToPool, uriPoolMembers, member)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	return &m, nil
+}
+
+// AddGTMPoolMember adds a member by config to a pool of the given record type.
+func (b *BigIP) AddGTMPoolMember(fullPathToPool string, config *GTMPoolMember, recordType GTMType) error {
+	return b.post(config, uriGtm, uriPool, string(recordType), fullPathToPool, uriPoolMembers)
+}
+
+// ModifyGTMPoolMember updates a member of a pool of the given record type by config.
+func (b *BigIP) ModifyGTMPoolMember(fullPathToPool, member string, config *GTMPoolMember, recordType GTMType) error {
+	return b.put(config, uriGtm, uriPool, string(recordType), fullPathToPool, uriPoolMembers, member)
+}
+
+// DeleteGTMPoolMember removes a member from a pool of the given record type.
+func (b *BigIP) DeleteGTMPoolMember(fullPathToPool, member string, recordType GTMType) error {
+	return b.delete(uriGtm, uriPool, string(recordType), fullPathToPool, uriPoolMembers, member)
+}
+
+// ********************************************************************************************************************
+// ********************************************                      **************************************************
+// ********************************************   GTM Data Centers   **************************************************
+// ********************************************                      **************************************************
+// ********************************************************************************************************************
+
+// GTMDatacenters contains a list of every gtm/datacenter on the BIG-IP system.
+type GTMDatacenters struct {
+	GTMDatacenters []GTMDatacenter `json:"items"`
+}
+
+// GTMDatacenter contains information about each gtm/datacenter.
+type GTMDatacenter struct {
+	Name             string `json:"name,omitempty"`
+	Partition        string `json:"partition,omitempty"`
+	FullPath         string `json:"fullPath,omitempty"`
+	Generation       int    `json:"generation,omitempty"`
+	AppService       string `json:"appService,omitempty"`
+	Contact          string `json:"contact,omitempty"`
+	Description      string `json:"description,omitempty"`
+	Disabled         bool   `json:"disabled,omitempty"`
+	Enabled          bool   `json:"enabled,omitempty"`
+	Location         string `json:"location,omitempty"`
+	ProberFallback   string `json:"proberFallback,omitempty"`
+	ProberPool       string `json:"proberPool,omitempty"`
+	ProberPreference string `json:"proberPreference,omitempty"`
+}
+
+// GetGTMDatacenters returns a list of all data centers.
+func (b *BigIP) GetGTMDatacenters() (*GTMDatacenters, error) {
+	var d GTMDatacenters
+	err, _ := b.getForEntity(&d, uriGtm, uriDatacenter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &d, nil
+}
+
+// GetGTMDatacenter gets a data center by name. Returns nil if the data center does not exist.
+func (b *BigIP) GetGTMDatacenter(name string) (*GTMDatacenter, error) {
+	var d GTMDatacenter
+	err, ok := b.getForEntity(&d, uriGtm, uriDatacenter, name)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	return &d, nil
+}
+
+// AddGTMDatacenter adds a data center by config to the BIG-IP system.
+func (b *BigIP) AddGTMDatacenter(config *GTMDatacenter) error {
+	return b.post(config, uriGtm, uriDatacenter)
+}
+
+// ModifyGTMDatacenter updates a data center by config.
+func (b *BigIP) ModifyGTMDatacenter(fullPath string, config *GTMDatacenter) error {
+	return b.put(config, uriGtm, uriDatacenter, fullPath)
+}
+
+// DeleteGTMDatacenter removes a data center. It must not have any servers.
+func (b *BigIP) DeleteGTMDatacenter(fullPath string) error {
+	return b.delete(uriGtm, uriDatacenter, fullPath)
+}
+
+// ********************************************************************************************************************
+// ********************************************                 *******************************************************
+// ********************************************   GTM Servers   *******************************************************
+// ********************************************                 *******************************************************
+// ********************************************************************************************************************
+
+// GTMServers contains a list of every gtm/server on the BIG-IP system.
+type GTMServers struct {
+	GTMServers []GTMServer `json:"items"`
+}
+
+// GTMServer contains information about each gtm/server: a BIG-IP system, or any other
+// host, whose virtual servers answer for GTM pool members.
+type GTMServer struct {
+	Name                      string                   `json:"name,omitempty"`
+	Partition                 string                   `json:"partition,omitempty"`
+	FullPath                  string                   `json:"fullPath,omitempty"`
+	Generation                int                      `json:"generation,omitempty"`
+	AppService                string                   `json:"appService,omitempty"`
+	Addresses                 []GTMServerAddress       `json:"addresses,omitempty"`
+	Datacenter                string                   `json:"datacenter,omitempty"`
+	Description               string                   `json:"description,omitempty"`
+	Devices                   []GTMServerDevice        `json:"devices,omitempty"`
+	Disabled                  bool                     `json:"disabled,omitempty"`
+	Enabled                   bool                     `json:"enabled,omitempty"`
+	ExposeRouteDomains        string                   `json:"exposeRouteDomains,omitempty"`
+	IqAllowPath               string                   `json:"iqAllowPath,omitempty"`
+	IqAllowServiceCheck       string                   `json:"iqAllowServiceCheck,omitempty"`
+	IqAllowSnmp               string                   `json:"iqAllowSnmp,omitempty"`
+	LimitCPUUsage             int                      `json:"limitCpuUsage,omitempty"`
+	LimitCPUUsageStatus       string                   `json:"limitCpuUsageStatus,omitempty"`
+	LimitMaxBps               uint64                   `json:"limitMaxBps,omitempty"`
+	LimitMaxBpsStatus         string                   `json:"limitMaxBpsStatus,omitempty"`
+	LimitMaxConnections       uint64                   `json:"limitMaxConnections,omitempty"`
+	LimitMaxConnectionsStatus string                   `json:"limitMaxConnectionsStatus,omitempty"`
+	LimitMaxPps               uint64                   `json:"limitMaxPps,omitempty"`
+	LimitMaxPpsStatus         string                   `json:"limitMaxPpsStatus,omitempty"`
+	LimitMemAvail             int                      `json:"limitMemAvail,omitempty"`
+	LimitMemAvailStatus       string                   `json:"limitMemAvailStatus,omitempty"`
+	LinkDiscovery             string                   `json:"linkDiscovery,omitempty"`
+	Monitor                   string                   `json:"monitor,omitempty"`
+	Product                   string                   `json:"product,omitempty"`
+	ProberFallback            string                   `json:"proberFallback,omitempty"`
+	ProberPool                string                   `json:"proberPool,omitempty"`
+	ProberPreference          string                   `json:"proberPreference,omitempty"`
+	VirtualServerDiscovery    string                   `json:"virtualServerDiscovery,omitempty"`
+	VirtualServers            []GTMServerVirtualServer `json:"virtualServers,omitempty"`
+}
+
+// GTMServerAddress is an address of a gtm/server. Translation is the address as seen from
+// the GTM when it differs, e.g. behind NAT; "none" otherwise.
+type GTMServerAddress struct {
+	Name        string `json:"name,omitempty"`
+	DeviceName  string `json:"deviceName,omitempty"`
+	Translation string `json:"translation,omitempty"`
+}
+
+// GTMServerDevice is a device of a gtm/server, such as one unit of a BIG-IP device group.
+type GTMServerDevice struct {
+	Name        string             `json:"name,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Addresses   []GTMServerAddress `json:"addresses,omitempty"`
+}
+
+// GetGTMServers returns a list of all servers.
+func (b *BigIP) GetGTMServers() (*GTMServers, error) {
+	var s GTMServers
+	err, _ := b.getForEntity(&s, uriGtm, uriServer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+// GetGTMServer gets a server by name. Returns nil if the server does not exist.
+func (b *BigIP) GetGTMServer(name string) (*GTMServer, error) {
+	var s GTMServer
+	err, ok := b.getForEntity(&s, uriGtm, uriServer, name)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	return &s, nil
+}
+
+// AddGTMServer adds a server by config to the BIG-IP system. Datacenter, Addresses (or
+// Devices) and Product are required.
+func (b *BigIP) AddGTMServer(config *GTMServer) error {
+	return b.post(config, uriGtm, uriServer)
+}
+
+// ModifyGTMServer updates a server by config.
+func (b *BigIP) ModifyGTMServer(fullPath string, config *GTMServer) error {
+	return b.put(config, uriGtm, uriServer, fullPath)
+}
+
+// DeleteGTMServer removes a server and its virtual servers. Its virtual servers must not
+// be members of any GTM pool.
+func (b *BigIP) DeleteGTMServer(fullPath string) error {
+	return b.delete(uriGtm, uriServer, fullPath)
+}
+
+// ********************************************************************************************************************
+// ********************************************                                ****************************************
+// ********************************************   GTM Server Virtual Servers   ****************************************
+// ********************************************                                ****************************************
+// ********************************************************************************************************************
+
+// GTMServerVirtualServers contains a list of every virtual server of a gtm/server.
+type GTMServerVirtualServers struct {
+	GTMServerVirtualServers []GTMServerVirtualServer `json:"items"`
+}
+
+// GTMServerVirtualServer contains information about each virtual server of a gtm/server.
+// For BIG-IP servers, Name is the full path of the LTM virtual server, e.g.
+// "/Common/app_80_vs". Destination is "<address>:<port>".
+type GTMServerVirtualServer struct {
+	Name                      string `json:"name,omitempty"`
+	FullPath                  string `json:"fullPath,omitempty"`
+	Generation                int    `json:"generation,omitempty"`
+	AppService                string `json:"appService,omitempty"`
+	Description               string `json:"description,omitempty"`
+	Destination               string `json:"destination,omitempty"`
+	Disabled                  bool   `json:"disabled,omitempty"`
+	Enabled                   bool   `json:"enabled,omitempty"`
+	ExplicitLinkName          string `json:"explicitLinkName,omitempty"`
+	LimitMaxBps               uint64 `json:"limitMaxBps,omitempty"`
+	LimitMaxBpsStatus         string `json:"limitMaxBpsStatus,omitempty"`
+	LimitMaxConnections       uint64 `json:"limitMaxConnections,omitempty"`
+	LimitMaxConnectionsStatus string `json:"limitMaxConnectionsStatus,omitempty"`
+	LimitMaxPps               uint64 `json:"limitMaxPps,omitempty"`
+	LimitMaxPpsStatus         string `json:"limitMaxPpsStatus,omitempty"`
+	Monitor                   string `json:"monitor,omitempty"`
+	TranslationAddress        string `json:"translationAddress,omitempty"`
+	TranslationPort           int    `json:"translationPort,omitempty"`
+}
+
+// GetGTMServerVirtualServers returns a list of all virtual servers of a server.
+func (b *BigIP) GetGTMServerVirtualServers(server string) (*GTMServerVirtualServers, error) {
+	var v GTMServerVirtualServers
+	err, _ := b.getForEntity(&v, uriGtm, uriServer, server, uriVirtualServers)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v, nil
+}
+
+// GetGTMServerVirtualServer gets a virtual server of a server by name. Returns nil if the
+// virtual server does not exist.
+func (b *BigIP) GetGTMServerVirtualServer(server, name string) (*GTMServerVirtualServer, error) {
+	var v GTMServerVirtualServer
+	err, ok := b.getForEntity(&v, uriGtm, uriServer, server, uriVirtualServers, name)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	return &v, nil
+}
+
+// AddGTMServerVirtualServer adds a virtual server by config to a server.
+func (b *BigIP) AddGTMServerVirtualServer(server string, config *GTMServerVirtualServer) error {
+	return b.post(config, uriGtm, uriServer, server, uriVirtualServers)
+}
+
+// ModifyGTMServerVirtualServer updates a virtual server of a server by config.
+func (b *BigIP) ModifyGTMServerVirtualServer(server, name string, config *GTMServerVirtualServer) error {
+	return b.put(config, uriGtm, uriServer, server, uriVirtualServers, name)
+}
+
+// DeleteGTMServerVirtualServer removes a virtual server from a server.
+func (b *BigIP) DeleteGTMServerVirtualServer(server, name string) error {
+	return b.delete(uriGtm, uriServer, server, uriVirtualServers, name)
+}
+
+// ********************************************************************************************************************
+// ********************************************                  ******************************************************
+// ********************************************   GTM Topology   ******************************************************
+// ********************************************                  ******************************************************
+// ********************************************************************************************************************
+
+// Topology criterion types. The LDNS side of a topology record matches the client's local
+// DNS server, the server side matches the pool, data center or virtual server answering.
+const (
+	TopologyContinent  = "continent"
+	TopologyCountry    = "country"
+	TopologyState      = "state"
+	TopologyISP        = "isp"
+	TopologyGeoIPISP   = "geoip-isp"
+	TopologySubnet     = "subnet"
+	TopologyRegion     = "region"
+	TopologyDatacenter = "datacenter"
+	TopologyPool       = "pool"
+)
+
+// GTMTopologyCriterion is the LDNS or server criterion of a topology record, or a member
+// of a region, e.g. {Type: TopologySubnet, Value: "10.0.0.0/8"}.
+type GTMTopologyCriterion struct {
+	Not   bool
+	Type  string
+	Value string
+}
+
+// GTMTopologyRecord is a gtm/topology record. Weight is the score given to answers whose
+// server matches when the client's LDNS matches.
+type GTMTopologyRecord struct {
+	LDNS   GTMTopologyCriterion
+	Server GTMTopologyCriterion
+	Weight int
+	Order  int
+}
+
+type gtmTopologyDTO struct {
+	Name       string `json:"name,omitempty"`
+	FullPath   string `json:"fullPath,omitempty"`
+	Generation int    `json:"generation,omitempty"`
+	Order      int    `json:"order,omitempty"`
+	Score      int    `json:"score,omitempty"`
+}
+
+type gtmTopologyDTOs struct {
+	Items []gtmTopologyDTO `json:"items"`
+}
+
+// GTMRegions contains a list of every gtm/region on the BIG-IP system.
+type GTMRegions struct {
+	GTMRegions []GTMRegion `json:"items"`
+}
+
+// GTMRegion contains information about each gtm/region: a named list of topology criteria
+// that topology records can match on as a whole.
+type GTMRegion struct {
+	Name          string            `json:"name,omitempty"`
+	Partition     string            `json:"partition,omitempty"`
+	FullPath      string            `json:"fullPath,omitempty"`
+	Generation    int               `json:"generation,omitempty"`
+	Description   string            `json:"description,omitempty"`
+	RegionMembers []GTMRegionMember `json:"regionMembers,omitempty"`
+}
+
+// GTMRegionMember is a member of a gtm/region. Name is a criterion in tmsh syntax, e.g.
+// "subnet 10.0.0.0/8" or "not country US"; see GTMTopologyCriterion.String.
+type GTMRegionMember struct {
+	Name string `json:"name,omitempty"`
+}
+
+// String returns the criterion in tmsh syntax, e.g. "not subnet 10.0.0.0/8".
+func (c GTMTopologyCriterion) String() string {
+	value := c.Value
+	if strings.ContainsAny(value, " \t") {
+		value = strconv.Quote(value)
+	}
+	s := c.Type + " " + value
+	if c.Not {
+		s = "not " + s
+	}
+
+	return s
+}
+
+// ParseGTMTopologyCriterion parses a criterion in tmsh syntax, e.g. "not subnet 10.0.0.0/8".
+func ParseGTMTopologyCriterion(s string) (GTMTopologyCriterion, error) {
+	var c GTMTopologyCriterion
+	fields := strings.Fields(s)
+	if len(fields) > 0 && fields[0] == "not" {
+		c.Not = true
+		fields = fields[1:]
+	}
+	if len(fields) < 2 {
+		return c, fmt.Errorf("invalid topology criterion %q", s)
+	}
+	c.Type = fields[0]
+	c.Value = strings.Join(fields[1:], " ")
+	if v, err := strconv.Unquote(c.Value); err == nil {
+		c.Value = v
+	}
+
+	return c, nil
+}
+
+// name returns the name of the record on the BIG-IP, which identifies it.
+func (r GTMTopologyRecord) name() string {
+	return fmt.Sprintf("ldns: %s server: %s", r.LDNS, r.Server)
+}
+
+func parseGTMTopologyRecord(dto gtmTopologyDTO) (GTMTopologyRecord, error) {
+	r := GTMTopologyRecord{Weight: dto.Score, Order: dto.Order}
+	parts := strings.SplitN(strings.TrimPrefix(dto.Name, "ldns:"), " server:", 2)
+	if len(parts) != 2 {
+		return r, fmt.Errorf("invalid topology record %q", dto.Name)
+	}
+
+	var err error
+	if r.LDNS, err = ParseGTMTopologyCriterion(parts[0]); err != nil {
+		return r, err
+	}
+	if r.Server, err = ParseGTMTopologyCriterion(parts[1]); err != nil {
+		return r, err
+	}
+
+	return r, nil
+}
+
+// GetGTMTopologyRecords returns a list of all topology records, in order.
+func (b *BigIP) GetGTMTopologyRecords() ([]GTMTopologyRecord, error) {
+	var dtos gtmTopologyDTOs
+	err, _ := b.getForEntity(&dtos, uriGtm, uriTopology)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]GTMTopologyRecord, 0, len(dtos.Items))
+	for _, dto := range dtos.Items {
+		r, err := parseGTMTopologyRecord(dto)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, r)
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Order < records[j].Order
+	})
+
+	return records, nil
+}
+
+// AddGTMTopologyRecord adds a topology record to the BIG-IP system.
+func (b *BigIP) AddGTMTopologyRecord(record GTMTopologyRecord) error {
+	config := &gtmTopologyDTO{
+		Name:  record.name(),
+		Order: record.Order,
+		Score: record.Weight,
+	}
+	return b.post(config, uriGtm, uriTopology)
+}
+
+// ModifyGTMTopologyRecord updates the weight and order of a topology record. The record is
+// identified by its LDNS and server criteria.
+func (b *BigIP) ModifyGTMTopologyRecord(record GTMTopologyRecord) error {
+	config := &gtmTopologyDTO{
+		Order: record.Order,
+		Score: record.Weight,
+	}
+	return b.put(config, uriGtm, uriTopology, record.name())
+}
+
+// DeleteGTMTopologyRecord removes a topology record. The record is identified by its LDNS
+// and server criteria.
+func (b *BigIP) DeleteGTMTopologyRecord(record GTMTopologyRecord) error {
+	return b.delete(uriGtm, uriTopology, record.name())
+}
+
+// GetGTMRegions returns a list of all regions.
+func (b *BigIP) GetGTMRegions() (*GTMRegions, error) {
+	var r GTMRegions
+	err, _ := b.getForEntity(&r, uriGtm, uriRegion)
+	if err != nil {
+		return nil, err
+	}
+
+	return &r, nil
+}
+
+// GetGTMRegion gets a region by name. Returns nil if the region does not exist.
+func (b *BigIP) GetGTMRegion(name string) (*GTMRegion, error) {
+	var r GTMRegion
+	err, ok := b.getForEntity(&r, uriGtm, uriRegion, name)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	return &r, nil
+}
+
+// AddGTMRegion adds a region by config to the BIG-IP system.
+func (b *BigIP) AddGTMRegion(config *GTMRegion) error {
+	return b.post(config, uriGtm, uriRegion)
+}
+
+// ModifyGTMRegion updates a region by config.
+func (b *BigIP) ModifyGTMRegion(fullPath string, config *GTMRegion) error {
+	return b.put(config, uriGtm, uriRegion, fullPath)
+}
+
+// DeleteGTMRegion removes a region. It must not be used by any topology record.
+func (b *BigIP) DeleteGTMRegion(fullPath string) error {
+	return b.delete(uriGtm, uriRegion, fullPath)
+}
+
+// MatchGTMTopologyByOrder returns the first topology record on the BIG-IP system, by
+// Order, whose LDNS criterion matches the client address or subnet, or nil if none does.
+// See MatchGTMTopologyRecordByOrder.
+func (b *BigIP) MatchGTMTopologyByOrder(client string) (*GTMTopologyRecord, error) {
+	records, err := b.GetGTMTopologyRecords()
+	if err != nil {
+		return nil, err
+	}
+	regions, err := b.GetGTMRegions()
+	if err != nil {
+		return nil, err
+	}
+
+	return MatchGTMTopologyRecordByOrder(records, regions.GTMRegions, client)
+}
+
+// MatchGTMTopologyRecordByOrder returns the first of records whose LDNS criterion matches
+// the client address or subnet, e.g. "10.1.1.1" or "10.1.0.0/16", or nil if none does.
+// Records are taken in the order given, as returned by GetGTMTopologyRecords. A subnet
+// criterion matches a client subnet it contains, and a region criterion matches if any of
+// its members do. Criteria that depend on the geolocation or ISP databases cannot be
+// evaluated locally and never match.
+//
+// This is order-only matching, as BIG-IP does with topology-longest-match disabled in the
+// gtm global-settings. With it enabled, the default, BIG-IP prefers the most specific
+// records and weighs the server side and scores of all matching records, which is not
+// modelled here.
+func MatchGTMTopologyRecordByOrder(records []GTMTopologyRecord, regions []GTMRegion, client string) (*GTMTopologyRecord, error) {
+	subnet, err := parseClientSubnet(client)
+	if err != nil {
+		return nil, err
+	}
+	byName := map[string]*GTMRegion{}
+	for i, r := range regions {
+		byName[r.FullPath] = &regions[i]
+		byName[r.Name] = &regions[i]
+	}
+
+	for i, r := range records {
+		match, err := matchTopologyCriterion(r.LDNS, subnet, byName, map[string]bool{})
+		if err != nil {
+			return nil, err
+		}
+		if match {
+			return &records[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+// matchTopologyCriterion reports whether c matches the client subnet. seen holds the
+// regions being evaluated, to stop on regions that contain themselves.
+func matchTopologyCriterion(c GTMTopologyCriterion, subnet *net.IPNet, regions map[string]*GTMRegion, seen map[string]bool) (bool, error) {
+	var match bool
+	switch c.Type {
+	case TopologySubnet:
+		n, err := parseClientSubnet(c.Value)
+		if err != nil {
+			return false, err
+		}
+		ones, bits := n.Mask.Size()
+		clientOnes, clientBits := subnet.Mask.Size()
+		match = bits == clientBits && ones <= clientOnes && n.Contains(subnet.IP)
+	case TopologyRegion:
+		region, ok := regions[c.Value]
+		if !ok {
+			return false, fmt.Errorf("region %s does not exist", c.Value)
+		}
+		if seen[region.FullPath] {
+			return false, fmt.Errorf("region %s contains itself", c.Value)
+		}
+		seen[region.FullPath] = true
+		for _, m := range region.RegionMembers {
+			member, err := ParseGTMTopologyCriterion(m.Name)
+			if err != nil {
+				return false, err
+			}
+			if match, err = matchTopologyCriterion(member, subnet, regions, seen); err != nil {
+				return false, err
+			}
+			if match {
+				break
+			}
+		}
+		delete(seen, region.FullPath)
+	default:
+		return false, nil
+	}
+
+	return match != c.Not, nil
+}
+
+// parseClientSubnet parses an address or a subnet in CIDR notation.
+func parseClientSubnet(s string) (*net.IPNet, error) {
+	if !strings.Contains(s, "/") {
+		ip := net.ParseIP(s)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid address %q", s)
+		}
+		if ip4 := ip.To4(); ip4 != nil {
+			return &net.IPNet{IP: ip4, Mask: net.CIDRMask(32, 32)}, nil
+		}
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}, nil
+	}
+	_, n, err := net.ParseCIDR(s)
+
+	return n, err
+}
+
+// ********************************************************************************************************************
+// ********************************************                  ******************************************************
+// ********************************************   GTM Monitors   ******************************************************
+// ********************************************                  ******************************************************
+// ********************************************************************************************************************
+
+// GTMMonitors contains a list of every gtm/monitor of a type on the BIG-IP system.
+type GTMMonitors struct {
+	GTMMonitors []GTMMonitor `json:"items"`
+}
+
+// GTMMonitor contains information about each gtm/monitor, regardless of type: http, https,
+// tcp, gateway-icmp, bigip, etc. Fields that do not apply to a type are omitted by the API.
+type GTMMonitor struct {
+	Name                   string `json:"name,omitempty"`
+	Partition              string `json:"partition,omitempty"`
+	FullPath               string `json:"fullPath,omitempty"`
+	Generation             int    `json:"generation,omitempty"`
+	AggregateDynamicRatios string `json:"aggregateDynamicRatios,omitempty"`
+	Cert                   string `json:"cert,omitempty"`
+	Cipherlist             string `json:"cipherlist,omitempty"`
+	Compatibility          string `json:"compatibility,omitempty"`
+	DefaultsFrom           string `json:"defaultsFrom,omitempty"`
+	Description            string `json:"description,omitempty"`
+	Destination            string `json:"destination,omitempty"`
+	IgnoreDownResponse     string `json:"ignoreDownResponse,omitempty"`
+	Interval               int    `json:"interval,omitempty"`
+	Key                    string `json:"key,omitempty"`
+	Password               string `json:"password,omitempty"`
+	ProbeAttempts          int    `json:"probeAttempts,omitempty"`
+	ProbeInterval          int    `json:"probeInterval,omitempty"`
+	ProbeTimeout           int    `json:"probeTimeout,omitempty"`
+	Recv                   string `json:"recv,omitempty"`
+	Reverse                string `json:"reverse,omitempty"`
+	Send                   string `json:"send,omitempty"`
+	Timeout                int    `json:"timeout,omitempty"`
+	Transparent            string `json:"transparent,omitempty"`
+	Username               string `json:"username,omitempty"`
+}
+
+// GetGTMMonitors returns a list of all GTM monitors of the given type, e.g. "http" or
+// "gateway-icmp".
+func (b *BigIP) GetGTMMonitors(monitorType string) (*GTMMonitors, error) {
+	var m GTMMonitors
+	err, _ := b.getForEntity(&m, uriGtm, uriMonitor, monitorType)
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// GetGTMMonitor gets a GTM monitor of the given type by name. Returns nil if the monitor
+// does not exist.
+func (b *BigIP) GetGTMMonitor(name, monitorType string) (*GTMMonitor, error) {
+	var m GTMMonitor
+	err, ok := b.getForEntity(&m, uriGtm, uriMonitor, monitorType, name)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	return &m, nil
+}
+
+// AddGTMMonitor adds a GTM monitor of the given type by config to the BIG-IP system.
+func (b *BigIP) AddGTMMonitor(config *GTMMonitor, monitorType string) error {
+	return b.post(config, uriGtm, uriMonitor, monitorType)
+}
+
+// ModifyGTMMonitor updates a GTM monitor of the given type by config.
+func (b *BigIP) ModifyGTMMonitor(fullPath string, config *GTMMonitor, monitorType string) error {
+	return b.put(config, uriGtm, uriMonitor, monitorType, fullPath)
+}
+
+// DeleteGTMMonitor removes a GTM monitor of the given type.
+func (b *BigIP) DeleteGTMMonitor(fullPath, monitorType string) error {
+	return b.delete(uriGtm, uriMonitor, monitorType, fullPath)
+}
+
+// ********************************************************************************************************************
+// ********************************************                      **************************************************
+// ********************************************   GTM Prober Pools   **************************************************
+// ********************************************                      **************************************************
+// ********************************************************************************************************************
+
+// GTMProberPools contains a list of every gtm/prober-pool on the BIG-IP system.
+type GTMProberPools struct {
+	GTMProberPools []GTMProberPool `json:"items"`
+}
+
+// GTMProberPool contains information about each gtm/prober-pool: the BIG-IP systems that
+// probe the servers of a data center or server. LoadBalancingMode is "global-availability"
+// or "round-robin".
+type GTMProberPool struct {
+	Name              string                `json:"name,omitempty"`
+	Partition         string                `json:"partition,omitempty"`
+	FullPath          string                `json:"fullPath,omitempty"`
+	Generation        int                   `json:"generation,omitempty"`
+	Description       string                `json:"description,omitempty"`
+	Disabled          bool                  `json:"disabled,omitempty"`
+	Enabled           bool                  `json:"enabled,omitempty"`
+	LoadBalancingMode string                `json:"loadBalancingMode,omitempty"`
+	Members           []GTMProberPoolMember `json:"members,omitempty"`
+}
+
+// GTMProberPoolMember is a member of a gtm/prober-pool. Name is a BIG-IP device of a GTM
+// server, e.g. "/Common/someltm".
+type GTMProberPoolMember struct {
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	Disabled    bool   `json:"disabled,omitempty"`
+	Enabled     bool   `json:"enabled,omitempty"`
+	Order       int    `json:"order"`
+}
+
+// gtmProberPoolDTO is a prober pool as returned with its members subcollection expanded.
+type gtmProberPoolDTO struct {
+	GTMProberPool
+	MembersReference struct {
+		Items []GTMProberPoolMember `json:"items"`
+	} `json:"membersReference"`
+}
+
+func (dto gtmProberPoolDTO) proberPool() GTMProberPool {
+	p := dto.GTMProberPool
+	if len(p.Members) == 0 {
+		p.Members = dto.MembersReference.Items
+	}
+
+	return p
+}
+
+// GetGTMProberPools returns a list of all prober pools.
+func (b *BigIP) GetGTMProberPools() (*GTMProberPools, error) {
+	var dtos struct {
+		Items []gtmProberPoolDTO `json:"items"`
+	}
+	err, _ := b.getForEntity(&dtos, uriGtm, uriProberPool, "?expandSubcollections=true")
+	if err != nil {
+		return nil, err
+	}
+
+	var p GTMProberPools
+	for _, dto := range dtos.Items {
+		p.GTMProberPools = append(p.GTMProberPools, dto.proberPool())
+	}
+
+	return &p, nil
+}
+
+// GetGTMProberPool gets a prober pool by name. Returns nil if the prober pool does not exist.
+func (b *BigIP) GetGTMProberPool(name string) (*GTMProberPool, error) {
+	var dto gtmProberPoolDTO
+	err, ok := b.getForEntity(&dto, uriGtm, uriProberPool, name, "?expandSubcollections=true")
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	p := dto.proberPool()
+	return &p, nil
+}
+
+// AddGTMProberPool adds a prober pool by config to the BIG-IP system.
+func (b *BigIP) AddGTMProberPool(config *GTMProberPool) error {
+	return b.post(config, uriGtm, uriProberPool)
+}
+
+// ModifyGTMProberPool updates a prober pool by config. Setting Members replaces all members.
+func (b *BigIP) ModifyGTMProberPool(fullPath string, config *GTMProberPool) error {
+	return b.put(config, uriGtm, uriProberPool, fullPath)
+}
+
+// DeleteGTMProberPool removes a prober pool. It must not be used by any data center or server.
+func (b *BigIP) DeleteGTMProberPool(fullPath string) error {
+	return b.delete(uriGtm, uriProberPool, fullPath)
+}
+
+// ********************************************************************************************************************
+// ********************************************                   *****************************************************
+// ********************************************   GTM Listeners   *****************************************************
+// ********************************************                   *****************************************************
+// ********************************************************************************************************************
+
+// GTMListeners contains a list of every gtm/listener on the BIG-IP system.
+type GTMListeners struct {
+	GTMListeners []GTMListener `json:"items"`
+}
+
+// GTMListener contains information about each gtm/listener: an address and port on which
+// the BIG-IP answers DNS queries. Profiles are typically a DNS profile and a UDP or TCP
+// profile.
+type GTMListener struct {
+	Name                     string    `json:"name,omitempty"`
+	Partition                string    `json:"partition,omitempty"`
+	FullPath                 string    `json:"fullPath,omitempty"`
+	Generation               int       `json:"generation,omitempty"`
+	Address                  string    `json:"address,omitempty"`
+	Advertise                string    `json:"advertise,omitempty"`
+	AutoLasthop              string    `json:"autoLasthop,omitempty"`
+	Description              string    `json:"description,omitempty"`
+	Disabled                 bool      `json:"disabled,omitempty"`
+	Enabled                  bool      `json:"enabled,omitempty"`
+	FallbackPersistence      string    `json:"fallbackPersistence,omitempty"`
+	IPProtocol               string    `json:"ipProtocol,omitempty"`
+	LastHopPool              string    `json:"lastHopPool,omitempty"`
+	Mask                     string    `json:"mask,omitempty"`
+	Persist                  []Profile `json:"persist,omitempty"`
+	Pool                     string    `json:"pool,omitempty"`
+	Port                     int       `json:"port,omitempty"`
+	Profiles                 []Profile `json:"profiles,omitempty"`
+	Rules                    []string  `json:"rules,omitempty"`
+	SourceAddressTranslation *struct {
+		Type string `json:"type,omitempty"`
+		Pool string `json:"pool,omitempty"`
+	} `json:"sourceAddressTranslation,omitempty"`
+	SourcePort       string   `json:"sourcePort,omitempty"`
+	TranslateAddress string   `json:"translateAddress,omitempty"`
+	TranslatePort    string   `json:"translatePort,omitempty"`
+	Vlans            []string `json:"vlans,omitempty"`
+	VlansDisabled    bool     `json:"vlansDisabled,omitempty"`
+	VlansEnabled     bool     `json:"vlansEnabled,omitempty"`
+}
+
+// GetGTMListeners returns a list of all listeners.
+func (b *BigIP) GetGTMListeners() (*GTMListeners, error) {
+	var l GTMListeners
+	err, _ := b.getForEntity(&l, uriGtm, uriListener)
+	if err != nil {
+		return nil, err
+	}
+
+	return &l, nil
+}
+
+// GetGTMListener gets a listener by name. Returns nil if the listener does not exist.
+func (b *BigIP) GetGTMListener(name string) (*GTMListener, error) {
+	var l GTMListener
+	err, ok := b.getForEntity(&l, uriGtm, uriListener, name)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	return &l, nil
+}
+
+// GetGTMListenerProfiles returns the profiles attached to a listener. Returns nil if the
+// listener does not exist.
+func (b *BigIP) GetGTMListenerProfiles(name string) (*Profiles, error) {
+	var p Profiles
+	err, ok := b.getForEntity(&p, uriGtm, uriListener, name, "profiles")
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	return &p, nil
+}
+
+// AddGTMListener adds a listener by config to the BIG-IP system.
+func (b *BigIP) AddGTMListener(config *GTMListener) error {
+	return b.post(config, uriGtm, uriListener)
+}
+
+// PatchGTMListener allows you to change any attribute of a listener. Sets only the
+// attributes specified; setting Profiles or Vlans replaces them.
+func (b *BigIP) PatchGTMListener(fullPath string, config *GTMListener) error {
+	return b.patch(config, uriGtm, uriListener, fullPath)
+}
+
+// DeleteGTMListener removes a listener.
+func (b *BigIP) DeleteGTMListener(fullPath string) error {
+	return b.delete(uriGtm, uriListener, fullPath)
+}
+
+// ********************************************************************************************************************
+// ********************************************               *********************************************************
+// ********************************************   GTM Stats   *********************************************************
+// ********************************************               *********************************************************
+// ********************************************************************************************************************
+
+// GTMStats contains the status and request counters of a GTM object. The request
+// counters are only reported for wide IPs, pools and pool members.
+type GTMStats struct {
+	AvailabilityState string
+	EnabledState      string
+	StatusReason      string
+	Requests          int64
+	Resolutions       int64
+	Persisted         int64
+	Preferred         int64
+	Alternate         int64
+	Fallback          int64
+	Dropped           int64
+	ReturnToDNS       int64
+	ReturnFromDNS     int64
+	CNAMEResolutions  int64
+}
+
+// GetGTMWideIPStats returns the current status of a wide IP. Returns nil if the wide IP
+// does not exist.
+func (b *BigIP) GetGTMWideIPStats(name string, recordType GTMType) (*GTMStats, error) {
+	return b.getGTMStats(uriGtm, uriWideIp, string(recordType), name)
+}
+
+// GetGTMPoolStats returns the current status of a pool. Returns nil if the pool does not exist.
+func (b *BigIP) GetGTMPoolStats(name string, recordType GTMType) (*GTMStats, error) {
+	return b.getGTMStats(uriGtm, uriPool, string(recordType), name)
+}
+
+// GetGTMPoolMemberStats returns the current status of a member of a pool. Returns nil if
+// the member does not exist.
+func (b *BigIP) GetGTMPoolMemberStats(fullPathToPool, member string, recordType GTMType) (*GTMStats, error) {
+	return b.getGTMStats(uriGtm, uriPool, string(recordType), fullPathToPool, uriPoolMembers, member)
+}
+
+// GetGTMServerStats returns the current status of a server. Returns nil if the server
+// does not exist.
+func (b *BigIP) GetGTMServerStats(name string) (*GTMStats, error) {
+	return b.getGTMStats(uriGtm, uriServer, name)
+}
+
+// GetGTMDatacenterStats returns the current status of a data center. Returns nil if the
+// data center does not exist.
+func (b *BigIP) GetGTMDatacenterStats(name string) (*GTMStats, error) {
+	return b.getGTMStats(uriGtm, uriDatacenter, name)
+}
+
+func (b *BigIP) getGTMStats(path ...string) (*GTMStats, error) {
+	stats, err := b.getStats(path...)
+	if err != nil {
+		return nil, err
+	}
+	if stats == nil {
+		return nil, nil
+	}
+
+	return &GTMStats{
+		AvailabilityState: stats["status.availabilityState"].Description,
+		EnabledState:      stats["status.enabledState"].Description,
+		StatusReason:      stats["status.statusReason"].Description,
+		Requests:          stats["requests"].Value,
+		Resolutions:       stats["resolutions"].Value,
+		Persisted:         stats["persisted"].Value,
+		Preferred:         stats["preferred"].Value,
+		Alternate:         stats["alternate"].Value,
+		Fallback:          stats["fallback"].Value,
+		Dropped:           stats["dropped"].Value,
+		ReturnToDNS:       stats["returnToDns"].Value,
+		ReturnFromDNS:     stats["returnFromDns"].Value,
+		CNAMEResolutions:  stats["cnameResolutions"].Value,
+	}, nil
+}
@@ -481,6 +481,669 @@ func (s *GTMTestSuite) TestDeleteGTMAPoolMember() {
 
 }
 
+func (s *GTMTestSuite) TestGetGTMPoolsAAAARecord() {
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"kind":"tm:gtm:pool:aaaa:aaaacollectionstate","items":[{"name":"app6_pool","partition":"Common","fullPath":"/Common/app6_pool","loadBalancingMode":"round-robin","enabled":true,"membersReference":{"link":"https://localhost/mgmt/tm/gtm/pool/aaaa/~Common~app6_pool/members?ver=13.1.0","isSubcollection":true}}]}`))
+	}
+
+	p, err := s.Client.GetGTMPools(AAAARecord)
+
+	assert := assert.New(s.T())
+	assert.Nil(err)
+	assert.Equal(fmt.Sprintf("/mgmt/tm/%s/%s/%s", uriGtm, uriPool, uriAAAARecord), s.LastRequest.URL.Path)
+	assert.Equal("/Common/app6_pool", p.GTMPools[0].FullPath)
+	assert.Equal("round-robin", p.GTMPools[0].LoadBalancingMode)
+	assert.True(p.GTMPools[0].MembersReference.IsSubcollection)
+}
+
+func (s *GTMTestSuite) TestGetGTMPoolNotFound() {
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"code":404,"message":"01020036:3: The requested GTM pool (/Common/missing) was not found.","errorStack":[]}`))
+	}
+
+	p, err := s.Client.GetGTMPool("/Common/missing", SRVRecord)
+
+	assert.Nil(s.T(), err)
+	assert.Nil(s.T(), p)
+	assert.Equal(s.T(), fmt.Sprintf("/mgmt/tm/%s/%s/%s/~Common~missing", uriGtm, uriPool, uriSrvRecord), s.LastRequest.URL.Path)
+}
+
+func (s *GTMTestSuite) TestAddGTMPoolMXRecord() {
+	config := &GTMPool{
+		Name:              "mail_pool",
+		Partition:         "Common",
+		LoadBalancingMode: "round-robin",
+		Members:           []GTMPoolMember{{Name: "mail.domain.com", Ratio: 2}},
+	}
+
+	err := s.Client.AddGTMPool(config, MXRecord)
+
+	assert := assert.New(s.T())
+	assert.Nil(err)
+	assert.Equal("POST", s.LastRequest.Method)
+	assert.Equal(fmt.Sprintf("/mgmt/tm/%s/%s/%s", uriGtm, uriPool, uriMXRecord), s.LastRequest.URL.Path)
+	assert.JSONEq(`{"name":"mail_pool","partition":"Common","loadBalancingMode":"round-robin","members":[{"name":"mail.domain.com","ratio":2}]}`, s.LastRequestBody)
+}
+
+func (s *GTMTestSuite) TestModifyGTMPoolCNAMERecord() {
+	err := s.Client.ModifyGTMPool("/Common/alias_pool", &GTMPool{FallbackMode: "none"}, CNAMERecord)
+
+	assert := assert.New(s.T())
+	assert.Nil(err)
+	assert.Equal("PUT", s.LastRequest.Method)
+	assert.Equal(fmt.Sprintf("/mgmt/tm/%s/%s/%s/~Common~alias_pool", uriGtm, uriPool, uriCNameRecord), s.LastRequest.URL.Path)
+	assert.JSONEq(`{"fallbackMode":"none"}`, s.LastRequestBody)
+}
+
+func (s *GTMTestSuite) TestGTMPoolMembersNAPTRRecord() {
+	pool := "/Common/sip_pool"
+	poolAPI := "~Common~sip_pool"
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			w.Write([]byte(`{"name":"sip.domain.com","fullPath":"sip.domain.com","flags":"s","service":"SIP+D2U","enabled":true,"memberOrder":0,"ratio":1}`))
+		}
+	}
+	assert := assert.New(s.T())
+
+	m, err := s.Client.GetGTMPoolMember(pool, "sip.domain.com", NAPTRRecord)
+	assert.Nil(err)
+	assert.Equal(fmt.Sprintf("/mgmt/tm/%s/%s/%s/%s/%s/sip.domain.com", uriGtm, uriPool, uriNaptrRecord, poolAPI, uriPoolMembers), s.LastRequest.URL.Path)
+	assert.Equal("SIP+D2U", m.Service)
+	assert.Equal("s", m.Flags)
+
+	err = s.Client.AddGTMPoolMember(pool, &GTMPoolMember{Name: "sip2.domain.com", Flags: "s", Service: "SIP+D2T"}, NAPTRRecord)
+	assert.Nil(err)
+	assert.Equal("POST", s.LastRequest.Method)
+	assert.Equal(fmt.Sprintf("/mgmt/tm/%s/%s/%s/%s/%s", uriGtm, uriPool, uriNaptrRecord, poolAPI, uriPoolMembers), s.LastRequest.URL.Path)
+	assert.JSONEq(`{"name":"sip2.domain.com","flags":"s","service":"SIP+D2T"}`, s.LastRequestBody)
+
+	err = s.Client.ModifyGTMPoolMember(pool, "sip2.domain.com", &GTMPoolMember{Disabled: true}, NAPTRRecord)
+	assert.Nil(err)
+	assert.Equal("PUT", s.LastRequest.Method)
+	assert.Equal(fmt.Sprintf("/mgmt/tm/%s/%s/%s/%s/%s/sip2.domain.com", uriGtm, uriPool, uriNaptrRecord, poolAPI, uriPoolMembers), s.LastRequest.URL.Path)
+	assert.JSONEq(`{"disabled":true}`, s.LastRequestBody)
+
+	err = s.Client.DeleteGTMPoolMember(pool, "sip2.domain.com", NAPTRRecord)
+	assert.Nil(err)
+	assert.Equal("DELETE", s.LastRequest.Method)
+	assert.Equal(fmt.Sprintf("/mgmt/tm/%s/%s/%s/%s/%s/sip2.domain.com", uriGtm, uriPool, uriNaptrRecord, poolAPI, uriPoolMembers), s.LastRequest.URL.Path)
+}
+
+func (s *GTMTestSuite) TestGetGTMPoolMembersAAAARecord() {
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"items":[{"name":"app6_80_vs","partition":"Common","subPath":"someltm:/Common","fullPath":"/Common/someltm:/Common/app6_80_vs","memberOrder":0,"ratio":1}]}`))
+	}
+
+	m, err := s.Client.GetGTMPoolMembers("/Common/app6_pool", AAAARecord)
+
+	assert := assert.New(s.T())
+	assert.Nil(err)
+	assert.Equal(fmt.Sprintf("/mgmt/tm/%s/%s/%s/~Common~app6_pool/%s", uriGtm, uriPool, uriAAAARecord, uriPoolMembers), s.LastRequest.URL.Path)
+	assert.Equal("/Common/someltm:/Common/app6_80_vs", m.GTMPoolMembers[0].FullPath)
+}
+
+func (s *GTMTestSuite) TestAddGTMDatacenter() {
+	err := s.Client.AddGTMDatacenter(&GTMDatacenter{Name: "dc1", Partition: "Common", Location: "Seattle", Enabled: true})
+
+	assert := assert.New(s.T())
+	assert.Nil(err)
+	assert.Equal("POST", s.LastRequest.Method)
+	assert.Equal(fmt.Sprintf("/mgmt/tm/%s/%s", uriGtm, uriDatacenter), s.LastRequest.URL.Path)
+	assert.JSONEq(`{"name":"dc1","partition":"Common","location":"Seattle","enabled":true}`, s.LastRequestBody)
+}
+
+func (s *GTMTestSuite) TestGetGTMDatacenters() {
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"items":[{"name":"dc1","partition":"Common","fullPath":"/Common/dc1","enabled":true,"proberFallback":"any-available","proberPreference":"inside-datacenter"}]}`))
+	}
+
+	d, err := s.Client.GetGTMDatacenters()
+
+	assert := assert.New(s.T())
+	assert.Nil(err)
+	assert.Equal(fmt.Sprintf("/mgmt/tm/%s/%s", uriGtm, uriDatacenter), s.LastRequest.URL.Path)
+	assert.Equal("/Common/dc1", d.GTMDatacenters[0].FullPath)
+	assert.Equal("inside-datacenter", d.GTMDatacenters[0].ProberPreference)
+}
+
+func (s *GTMTestSuite) TestGetGTMServer() {
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"name":"someltm","partition":"Common","fullPath":"/Common/someltm","addresses":[{"name":"10.1.1.10","deviceName":"someltm","translation":"none"}],"datacenter":"/Common/dc1","enabled":true,"linkDiscovery":"disabled","monitor":"/Common/bigip ","product":"bigip","virtualServerDiscovery":"enabled","virtualServersReference":{"link":"https://localhost/mgmt/tm/gtm/server/~Common~someltm/virtual-servers?ver=13.1.0","isSubcollection":true}}`))
+	}
+
+	server, err := s.Client.GetGTMServer("/Common/someltm")
+
+	assert := assert.New(s.T())
+	assert.Nil(err)
+	assert.Equal(fmt.Sprintf("/mgmt/tm/%s/%s/~Common~someltm", uriGtm, uriServer), s.LastRequest.URL.Path)
+	assert.Equal("/Common/dc1", server.Datacenter)
+	assert.Equal([]GTMServerAddress{{Name: "10.1.1.10", DeviceName: "someltm", Translation: "none"}}, server.Addresses)
+	assert.Equal("bigip", server.Product)
+	assert.Equal("enabled", server.VirtualServerDiscovery)
+}
+
+func (s *GTMTestSuite) TestAddGTMServer() {
+	config := &GTMServer{
+		Name:                   "someltm",
+		Datacenter:             "/Common/dc1",
+		Addresses:              []GTMServerAddress{{Name: "10.1.1.10", DeviceName: "someltm"}},
+		Product:                "bigip",
+		Monitor:                "/Common/bigip",
+		VirtualServerDiscovery: "disabled",
+		LinkDiscovery:          "disabled",
+	}
+
+	err := s.Client.AddGTMServer(config)
+
+	assert := assert.New(s.T())
+	assert.Nil(err)
+	assert.Equal("POST", s.LastRequest.Method)
+	assert.Equal(fmt.Sprintf("/mgmt/tm/%s/%s", uriGtm, uriServer), s.LastRequest.URL.Path)
+	assert.JSONEq(`{"name":"someltm","datacenter":"/Common/dc1","addresses":[{"name":"10.1.1.10","deviceName":"someltm"}],"product":"bigip","monitor":"/Common/bigip","virtualServerDiscovery":"disabled","linkDiscovery":"disabled"}`, s.LastRequestBody)
+}
+
+func (s *GTMTestSuite) TestDeleteGTMServer() {
+	err := s.Client.DeleteGTMServer("/Common/someltm")
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), "DELETE", s.LastRequest.Method)
+	assert.Equal(s.T(), fmt.Sprintf("/mgmt/tm/%s/%s/~Common~someltm", uriGtm, uriServer), s.LastRequest.URL.Path)
+}
+
+func (s *GTMTestSuite) TestGTMServerVirtualServers() {
+	server := "/Common/someltm"
+	path := fmt.Sprintf("/mgmt/tm/%s/%s/~Common~someltm/%s", uriGtm, uriServer, uriVirtualServers)
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			w.Write([]byte(`{"items":[{"name":"/Common/baseapp_80_vs","fullPath":"/Common/baseapp_80_vs","destination":"10.1.1.100:80","enabled":true,"translationAddress":"none","translationPort":0}]}`))
+		}
+	}
+	assert := assert.New(s.T())
+
+	v, err := s.Client.GetGTMServerVirtualServers(server)
+	assert.Nil(err)
+	assert.Equal(path, s.LastRequest.URL.Path)
+	assert.Equal("10.1.1.100:80", v.GTMServerVirtualServers[0].Destination)
+
+	err = s.Client.AddGTMServerVirtualServer(server, &GTMServerVirtualServer{Name: "/Common/baseapp_443_vs", Destination: "10.1.1.100:443"})
+	assert.Nil(err)
+	assert.Equal("POST", s.LastRequest.Method)
+	assert.Equal(path, s.LastRequest.URL.Path)
+	assert.JSONEq(`{"name":"/Common/baseapp_443_vs","destination":"10.1.1.100:443"}`, s.LastRequestBody)
+
+	err = s.Client.ModifyGTMServerVirtualServer(server, "/Common/baseapp_443_vs", &GTMServerVirtualServer{Destination: "10.1.1.100:443", Monitor: "/Common/https"})
+	assert.Nil(err)
+	assert.Equal("PUT", s.LastRequest.Method)
+	assert.Equal(path+"/~Common~baseapp_443_vs", s.LastRequest.URL.Path)
+	assert.JSONEq(`{"destination":"10.1.1.100:443","monitor":"/Common/https"}`, s.LastRequestBody)
+
+	err = s.Client.DeleteGTMServerVirtualServer(server, "/Common/baseapp_443_vs")
+	assert.Nil(err)
+	assert.Equal("DELETE", s.LastRequest.Method)
+	assert.Equal(path+"/~Common~baseapp_443_vs", s.LastRequest.URL.Path)
+}
+
+func (s *GTMTestSuite) TestGetGTMTopologyRecords() {
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"items":[
+			{"name":"ldns: not country US server: pool /Common/intl_pool","fullPath":"ldns: not country US server: pool /Common/intl_pool","order":2,"score":50},
+			{"name":"ldns: subnet 10.0.0.0/8 server: datacenter /Common/dc1","fullPath":"ldns: subnet 10.0.0.0/8 server: datacenter /Common/dc1","order":1,"score":100},
+			{"name":"ldns: state \"US/New York\" server: region /Common/east","order":3,"score":10}]}`))
+	}
+
+	records, err := s.Client.GetGTMTopologyRecords()
+
+	assert := assert.New(s.T())
+	assert.Nil(err)
+	assert.Equal(fmt.Sprintf("/mgmt/tm/%s/%s", uriGtm, uriTopology), s.LastRequest.URL.Path)
+	assert.Equal([]GTMTopologyRecord{
+		{
+			LDNS:   GTMTopologyCriterion{Type: TopologySubnet, Value: "10.0.0.0/8"},
+			Server: GTMTopologyCriterion{Type: TopologyDatacenter, Value: "/Common/dc1"},
+			Weight: 100,
+			Order:  1,
+		},
+		{
+			LDNS:   GTMTopologyCriterion{Not: true, Type: TopologyCountry, Value: "US"},
+			Server: GTMTopologyCriterion{Type: TopologyPool, Value: "/Common/intl_pool"},
+			Weight: 50,
+			Order:  2,
+		},
+		{
+			LDNS:   GTMTopologyCriterion{Type: TopologyState, Value: "US/New York"},
+			Server: GTMTopologyCriterion{Type: TopologyRegion, Value: "/Common/east"},
+			Weight: 10,
+			Order:  3,
+		},
+	}, records)
+}
+
+func (s *GTMTestSuite) TestAddGTMTopologyRecord() {
+	record := GTMTopologyRecord{
+		LDNS:   GTMTopologyCriterion{Not: true, Type: TopologyState, Value: "US/New York"},
+		Server: GTMTopologyCriterion{Type: TopologyPool, Value: "/Common/west_pool"},
+		Weight: 100,
+	}
+
+	err := s.Client.AddGTMTopologyRecord(record)
+
+	assert := assert.New(s.T())
+	assert.Nil(err)
+	assert.Equal("POST", s.LastRequest.Method)
+	assert.Equal(fmt.Sprintf("/mgmt/tm/%s/%s", uriGtm, uriTopology), s.LastRequest.URL.Path)
+	assert.JSONEq(`{"name":"ldns: not state \"US/New York\" server: pool /Common/west_pool","score":100}`, s.LastRequestBody)
+}
+
+func (s *GTMTestSuite) TestDeleteGTMTopologyRecord() {
+	record := GTMTopologyRecord{
+		LDNS:   GTMTopologyCriterion{Type: TopologySubnet, Value: "10.0.0.0/8"},
+		Server: GTMTopologyCriterion{Type: TopologyDatacenter, Value: "/Common/dc1"},
+	}
+
+	err := s.Client.DeleteGTMTopologyRecord(record)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), "DELETE", s.LastRequest.Method)
+	assert.Equal(s.T(), fmt.Sprintf("/mgmt/tm/%s/%s/ldns: subnet 10.0.0.0~8 server: datacenter ~Common~dc1", uriGtm, uriTopology), s.LastRequest.URL.Path)
+}
+
+func (s *GTMTestSuite) TestAddGTMRegion() {
+	config := &GTMRegion{
+		Name: "internal",
+		RegionMembers: []GTMRegionMember{
+			{Name: GTMTopologyCriterion{Type: TopologySubnet, Value: "10.0.0.0/8"}.String()},
+			{Name: GTMTopologyCriterion{Type: TopologyRegion, Value: "/Common/lab"}.String()},
+		},
+	}
+
+	err := s.Client.AddGTMRegion(config)
+
+	assert := assert.New(s.T())
+	assert.Nil(err)
+	assert.Equal("POST", s.LastRequest.Method)
+	assert.Equal(fmt.Sprintf("/mgmt/tm/%s/%s", uriGtm, uriRegion), s.LastRequest.URL.Path)
+	assert.JSONEq(`{"name":"internal","regionMembers":[{"name":"subnet 10.0.0.0/8"},{"name":"region /Common/lab"}]}`, s.LastRequestBody)
+}
+
+func (s *GTMTestSuite) TestMatchGTMTopologyRecordByOrder() {
+	subnet := func(not bool, value string) GTMTopologyCriterion {
+		return GTMTopologyCriterion{Not: not, Type: TopologySubnet, Value: value}
+	}
+	region := func(value string) GTMTopologyCriterion {
+		return GTMTopologyCriterion{Type: TopologyRegion, Value: value}
+	}
+	dc := func(value string) GTMTopologyCriterion {
+		return GTMTopologyCriterion{Type: TopologyDatacenter, Value: value}
+	}
+	records := []GTMTopologyRecord{
+		{LDNS: GTMTopologyCriterion{Type: TopologyCountry, Value: "US"}, Server: dc("/Common/us"), Order: 1},
+		{LDNS: subnet(false, "10.1.0.0/16"), Server: dc("/Common/dc1"), Order: 2},
+		{LDNS: region("/Common/internal"), Server: dc("/Common/dc2"), Order: 3},
+		{LDNS: subnet(true, "192.168.0.0/16"), Server: dc("/Common/dc3"), Order: 4},
+	}
+	regions := []GTMRegion{
+		{Name: "internal", FullPath: "/Common/internal", RegionMembers: []GTMRegionMember{{Name: "region /Common/lab"}, {Name: "subnet 172.16.0.0/12"}}},
+		{Name: "lab", FullPath: "/Common/lab", RegionMembers: []GTMRegionMember{{Name: "subnet 10.0.0.0/8"}}},
+	}
+	assert := assert.New(s.T())
+
+	for client, dc := range map[string]string{
+		"10.1.2.3":       "/Common/dc1",
+		"10.1.2.0/24":    "/Common/dc1",
+		"10.0.0.0/8":     "/Common/dc2",
+		"10.2.0.1":       "/Common/dc2",
+		"172.16.5.5":     "/Common/dc2",
+		"8.8.8.8":        "/Common/dc3",
+		"2001:db8::1":    "/Common/dc3",
+		"192.168.1.1":    "",
+		"192.168.0.0/15": "/Common/dc3",
+	} {
+		r, err := MatchGTMTopologyRecordByOrder(records, regions, client)
+		assert.Nil(err, client)
+		if dc == "" {
+			assert.Nil(r, client)
+			continue
+		}
+		if assert.NotNil(r, client) {
+			assert.Equal(dc, r.Server.Value, client)
+		}
+	}
+
+	_, err := MatchGTMTopologyRecordByOrder(records, regions, "not-an-address")
+	assert.NotNil(err)
+	_, err = MatchGTMTopologyRecordByOrder(records, regions[1:], "172.16.0.1")
+	assert.EqualError(err, "region /Common/internal does not exist")
+}
+
+func (s *GTMTestSuite) TestGetGTMMonitor() {
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"name":"app_https","partition":"Common","fullPath":"/Common/app_https","defaultsFrom":"/Common/https","destination":"*:*","interval":30,"probeTimeout":5,"recv":"200 OK","send":"GET /health HTTP/1.1\r\nHost: app\r\n\r\n","timeout":120,"cipherlist":"DEFAULT:+SHA:+3DES:+kEDH","compatibility":"enabled","ignoreDownResponse":"disabled","reverse":"disabled","transparent":"disabled"}`))
+	}
+
+	m, err := s.Client.GetGTMMonitor("/Common/app_https", "https")
+
+	assert := assert.New(s.T())
+	assert.Nil(err)
+	assert.Equal(fmt.Sprintf("/mgmt/tm/%s/%s/https/~Common~app_https", uriGtm, uriMonitor), s.LastRequest.URL.Path)
+	assert.Equal("/Common/https", m.DefaultsFrom)
+	assert.Equal(30, m.Interval)
+	assert.Equal(120, m.Timeout)
+	assert.Equal("200 OK", m.Recv)
+}
+
+func (s *GTMTestSuite) TestAddGTMMonitor() {
+	err := s.Client.AddGTMMonitor(&GTMMonitor{Name: "gw", DefaultsFrom: "/Common/gateway_icmp", Interval: 10, Timeout: 31, ProbeAttempts: 3}, "gateway-icmp")
+
+	assert := assert.New(s.T())
+	assert.Nil(err)
+	assert.Equal("POST", s.LastRequest.Method)
+	assert.Equal(fmt.Sprintf("/mgmt/tm/%s/%s/gateway-icmp", uriGtm, uriMonitor), s.LastRequest.URL.Path)
+	assert.JSONEq(`{"name":"gw","defaultsFrom":"/Common/gateway_icmp","interval":10,"timeout":31,"probeAttempts":3}`, s.LastRequestBody)
+}
+
+func (s *GTMTestSuite) TestDeleteGTMMonitor() {
+	err := s.Client.DeleteGTMMonitor("/Common/app_tcp", "tcp")
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), "DELETE", s.LastRequest.Method)
+	assert.Equal(s.T(), fmt.Sprintf("/mgmt/tm/%s/%s/tcp/~Common~app_tcp", uriGtm, uriMonitor), s.LastRequest.URL.Path)
+}
+
+func (s *GTMTestSuite) TestGetGTMProberPool() {
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"name":"probers","partition":"Common","fullPath":"/Common/probers","enabled":true,"loadBalancingMode":"global-availability","membersReference":{"link":"https://localhost/mgmt/tm/gtm/prober-pool/~Common~probers/members?ver=13.1.0","isSubcollection":true,"items":[{"name":"/Common/ltm1","order":0,"enabled":true},{"name":"/Common/ltm2","order":1,"enabled":true}]}}`))
+	}
+
+	p, err := s.Client.GetGTMProberPool("/Common/probers")
+
+	assert := assert.New(s.T())
+	assert.Nil(err)
+	assert.Equal(fmt.Sprintf("/mgmt/tm/%s/%s/~Common~probers", uriGtm, uriProberPool), s.LastRequest.URL.Path)
+	assert.Equal("expandSubcollections=true", s.LastRequest.URL.RawQuery)
+	assert.Equal("global-availability", p.LoadBalancingMode)
+	assert.Equal([]GTMProberPoolMember{{Name: "/Common/ltm1", Enabled: true}, {Name: "/Common/ltm2", Enabled: true, Order: 1}}, p.Members)
+}
+
+func (s *GTMTestSuite) TestAddGTMProberPool() {
+	config := &GTMProberPool{
+		Name:              "probers",
+		LoadBalancingMode: "round-robin",
+		Members:           []GTMProberPoolMember{{Name: "/Common/ltm1"}, {Name: "/Common/ltm2", Order: 1}},
+	}
+
+	err := s.Client.AddGTMProberPool(config)
+
+	assert := assert.New(s.T())
+	assert.Nil(err)
+	assert.Equal("POST", s.LastRequest.Method)
+	assert.Equal(fmt.Sprintf("/mgmt/tm/%s/%s", uriGtm, uriProberPool), s.LastRequest.URL.Path)
+	assert.JSONEq(`{"name":"probers","loadBalancingMode":"round-robin","members":[{"name":"/Common/ltm1","order":0},{"name":"/Common/ltm2","order":1}]}`, s.LastRequestBody)
+}
+
+func (s *GTMTestSuite) TestAddGTMListener() {
+	config := &GTMListener{
+		Name:         "dns_udp",
+		Address:      "10.1.1.53",
+		Port:         53,
+		IPProtocol:   "udp",
+		Mask:         "255.255.255.255",
+		Profiles:     []Profile{{Name: "dns"}, {Name: "udp_gtm_dns"}},
+		Vlans:        []string{"/Common/external"},
+		VlansEnabled: true,
+	}
+
+	err := s.Client.AddGTMListener(config)
+
+	assert := assert.New(s.T())
+	assert.Nil(err)
+	assert.Equal("POST", s.LastRequest.Method)
+	assert.Equal(fmt.Sprintf("/mgmt/tm/%s/%s", uriGtm, uriListener), s.LastRequest.URL.Path)
+	assert.JSONEq(`{"name":"dns_udp","address":"10.1.1.53","port":53,"ipProtocol":"udp","mask":"255.255.255.255","profiles":[{"name":"dns"},{"name":"udp_gtm_dns"}],"vlans":["/Common/external"],"vlansEnabled":true}`, s.LastRequestBody)
+}
+
+func (s *GTMTestSuite) TestGetGTMListenerProfiles() {
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"items":[{"name":"dns","partition":"Common","fullPath":"/Common/dns","context":"all"},{"name":"udp_gtm_dns","partition":"Common","fullPath":"/Common/udp_gtm_dns","context":"all"}]}`))
+	}
+
+	p, err := s.Client.GetGTMListenerProfiles("/Common/dns_udp")
+
+	assert := assert.New(s.T())
+	assert.Nil(err)
+	assert.Equal(fmt.Sprintf("/mgmt/tm/%s/%s/~Common~dns_udp/profiles", uriGtm, uriListener), s.LastRequest.URL.Path)
+	assert.Equal("/Common/udp_gtm_dns", p.Profiles[1].FullPath)
+}
+
+func (s *GTMTestSuite) TestPatchGTMListener() {
+	err := s.Client.PatchGTMListener("/Common/dns_udp", &GTMListener{Vlans: []string{"/Common/internal"}, VlansDisabled: true})
+
+	assert := assert.New(s.T())
+	assert.Nil(err)
+	assert.Equal("PATCH", s.LastRequest.Method)
+	assert.Equal(fmt.Sprintf("/mgmt/tm/%s/%s/~Common~dns_udp", uriGtm, uriListener), s.LastRequest.URL.Path)
+	assert.JSONEq(`{"vlans":["/Common/internal"],"vlansDisabled":true}`, s.LastRequestBody)
+}
+
+const wideIPWithPools = `{"name":"app.domain.com","partition":"Common","fullPath":"/Common/app.domain.com","generation":%d,"poolLbMode":"ratio",
+	"aliases":["app2.domain.com"],
+	"pools":[{"name":"east_pool","partition":"Common","ratio":1},{"name":"west_pool","partition":"Common","order":1,"ratio":1}]}`
+
+func (s *GTMTestSuite) TestAddPoolToWideIP() {
+	var requests []string
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if r.Method == "GET" {
+			fmt.Fprintf(w, wideIPWithPools, 7)
+		}
+	}
+
+	err := s.Client.AddPoolToWideIP("/Common/app.domain.com", ARecord, "/Common/dr_pool", 3)
+
+	assert := assert.New(s.T())
+	assert.Nil(err)
+	path := fmt.Sprintf("/mgmt/tm/%s/%s/%s/~Common~app.domain.com", uriGtm, uriWideIp, uriARecord)
+	assert.Equal([]string{"GET " + path, "GET " + path, "PATCH " + path}, requests)
+	assert.JSONEq(`{"generation":7,"pools":[
+		{"name":"east_pool","partition":"Common","ratio":1,"nameReference":{}},
+		{"name":"west_pool","partition":"Common","order":1,"ratio":1,"nameReference":{}},
+		{"name":"dr_pool","partition":"Common","order":2,"ratio":3,"nameReference":{}}]}`, s.LastRequestBody)
+}
+
+func (s *GTMTestSuite) TestAddPoolToWideIPAlreadyMember() {
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, wideIPWithPools, 7)
+	}
+
+	err := s.Client.AddPoolToWideIP("/Common/app.domain.com", ARecord, "west_pool", 0)
+
+	assert.EqualError(s.T(), err, "pool west_pool is already a member of wide IP /Common/app.domain.com")
+	assert.Equal(s.T(), "GET", s.LastRequest.Method)
+}
+
+func (s *GTMTestSuite) TestRemovePoolFromWideIPRetriesOnConcurrentChange() {
+	var gets int
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			gets++
+			// The wide IP changes between the first read and the check before the patch.
+			generation := 7
+			if gets > 1 {
+				generation = 8
+			}
+			fmt.Fprintf(w, wideIPWithPools, generation)
+		}
+	}
+
+	err := s.Client.RemovePoolFromWideIP("/Common/app.domain.com", ARecord, "/Common/east_pool")
+
+	assert := assert.New(s.T())
+	assert.Nil(err)
+	assert.Equal(3, gets)
+	assert.Equal("PATCH", s.LastRequest.Method)
+	assert.JSONEq(`{"generation":8,"pools":[{"name":"west_pool","partition":"Common","ratio":1,"nameReference":{}}]}`, s.LastRequestBody)
+}
+
+func (s *GTMTestSuite) TestEditWideIPGivesUp() {
+	var gets int
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		gets++
+		fmt.Fprintf(w, wideIPWithPools, gets)
+	}
+
+	err := s.Client.SetWideIPPoolRatio("/Common/app.domain.com", ARecord, "east_pool", 5)
+
+	assert.EqualError(s.T(), err, "wide IP /Common/app.domain.com kept changing, gave up after 3 attempts")
+	assert.Equal(s.T(), 3, gets)
+}
+
+func (s *GTMTestSuite) TestSetWideIPPoolOrder() {
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			fmt.Fprintf(w, wideIPWithPools, 7)
+		}
+	}
+	assert := assert.New(s.T())
+
+	err := s.Client.SetWideIPPoolOrder("/Common/app.domain.com", ARecord, []string{"west_pool", "/Common/east_pool"})
+	assert.Nil(err)
+	assert.JSONEq(`{"generation":7,"pools":[
+		{"name":"west_pool","partition":"Common","ratio":1,"nameReference":{}},
+		{"name":"east_pool","partition":"Common","order":1,"ratio":1,"nameReference":{}}]}`, s.LastRequestBody)
+
+	s.LastRequest = nil
+	err = s.Client.SetWideIPPoolOrder("/Common/app.domain.com", ARecord, []string{"west_pool"})
+	assert.EqualError(err, "wide IP /Common/app.domain.com has 2 pools, got 1")
+	assert.Equal("GET", s.LastRequest.Method)
+
+	err = s.Client.SetWideIPPoolOrder("/Common/app.domain.com", ARecord, []string{"west_pool", "west_pool"})
+	assert.EqualError(err, "pool west_pool is listed more than once")
+}
+
+func (s *GTMTestSuite) TestSetWideIPPoolRatio() {
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			fmt.Fprintf(w, wideIPWithPools, 7)
+		}
+	}
+
+	err := s.Client.SetWideIPPoolRatio("/Common/app.domain.com", AAAARecord, "west_pool", 4)
+
+	assert := assert.New(s.T())
+	assert.Nil(err)
+	assert.Equal(fmt.Sprintf("/mgmt/tm/%s/%s/%s/~Common~app.domain.com", uriGtm, uriWideIp, uriAAAARecord), s.LastRequest.URL.Path)
+	assert.JSONEq(`{"generation":7,"pools":[
+		{"name":"east_pool","partition":"Common","ratio":1,"nameReference":{}},
+		{"name":"west_pool","partition":"Common","order":1,"ratio":4,"nameReference":{}}]}`, s.LastRequestBody)
+}
+
+func (s *GTMTestSuite) TestWideIPAliasesAndIRules() {
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			fmt.Fprintf(w, wideIPWithPools, 7)
+		}
+	}
+	assert := assert.New(s.T())
+
+	assert.Nil(s.Client.AddWideIPAlias("/Common/app.domain.com", ARecord, "*.app.domain.com"))
+	assert.JSONEq(`{"generation":7,"aliases":["app2.domain.com","*.app.domain.com"]}`, s.LastRequestBody)
+
+	assert.Nil(s.Client.RemoveWideIPAlias("/Common/app.domain.com", ARecord, "app2.domain.com"))
+	assert.JSONEq(`{"generation":7,"aliases":[]}`, s.LastRequestBody)
+
+	assert.EqualError(s.Client.RemoveWideIPAlias("/Common/app.domain.com", ARecord, "other.domain.com"), "other.domain.com is not set")
+
+	assert.Nil(s.Client.AddWideIPIRule("/Common/app.domain.com", ARecord, "/Common/geo_rule"))
+	assert.JSONEq(`{"generation":7,"rules":["/Common/geo_rule"]}`, s.LastRequestBody)
+}
+
+const gtmWideIPStatsResponse = `{
+	"kind": "tm:gtm:wideip:a:astats",
+	"selfLink": "https://localhost/mgmt/tm/gtm/wideip/a/~Common~app.domain.com/stats?ver=13.1.0",
+	"entries": {
+		"https://localhost/mgmt/tm/gtm/wideip/a/~Common~app.domain.com/stats": {
+			"nestedStats": {
+				"entries": {
+					"alternate": {"value": 2},
+					"cnameResolutions": {"value": 0},
+					"dropped": {"value": 1},
+					"fallback": {"value": 3},
+					"persisted": {"value": 0},
+					"preferred": {"value": 120},
+					"requests": {"value": 126},
+					"resolutions": {"value": 125},
+					"returnFromDns": {"value": 0},
+					"returnToDns": {"value": 1},
+					"status.availabilityState": {"description": "offline"},
+					"status.enabledState": {"description": "enabled"},
+					"status.statusReason": {"description": "No enabled pools available"},
+					"tmName": {"description": "/Common/app.domain.com"}
+				}
+			}
+		}
+	}
+}`
+
+func (s *GTMTestSuite) TestGetGTMWideIPStats() {
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(gtmWideIPStatsResponse))
+	}
+
+	stats, err := s.Client.GetGTMWideIPStats("/Common/app.domain.com", ARecord)
+
+	assert := assert.New(s.T())
+	assert.Nil(err)
+	assert.Equal(fmt.Sprintf("/mgmt/tm/%s/%s/%s/~Common~app.domain.com/stats", uriGtm, uriWideIp, uriARecord), s.LastRequest.URL.Path)
+	assert.Equal(&GTMStats{
+		AvailabilityState: "offline",
+		EnabledState:      "enabled",
+		StatusReason:      "No enabled pools available",
+		Requests:          126,
+		Resolutions:       125,
+		Preferred:         120,
+		Alternate:         2,
+		Fallback:          3,
+		Dropped:           1,
+		ReturnToDNS:       1,
+	}, stats)
+}
+
+func (s *GTMTestSuite) TestGetGTMStatsPaths() {
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(gtmWideIPStatsResponse))
+	}
+	assert := assert.New(s.T())
+
+	_, err := s.Client.GetGTMPoolStats("/Common/app_pool", AAAARecord)
+	assert.Nil(err)
+	assert.Equal(fmt.Sprintf("/mgmt/tm/%s/%s/%s/~Common~app_pool/stats", uriGtm, uriPool, uriAAAARecord), s.LastRequest.URL.Path)
+
+	_, err = s.Client.GetGTMPoolMemberStats("/Common/app_pool", "/Common/someltm:/Common/app_80_vs", ARecord)
+	assert.Nil(err)
+	assert.Equal(fmt.Sprintf("/mgmt/tm/%s/%s/%s/~Common~app_pool/%s/~Common~someltm:~Common~app_80_vs/stats", uriGtm, uriPool, uriARecord, uriPoolMembers), s.LastRequest.URL.Path)
+
+	_, err = s.Client.GetGTMServerStats("/Common/someltm")
+	assert.Nil(err)
+	assert.Equal(fmt.Sprintf("/mgmt/tm/%s/%s/~Common~someltm/stats", uriGtm, uriServer), s.LastRequest.URL.Path)
+
+	stats, err := s.Client.GetGTMDatacenterStats("/Common/dc1")
+	assert.Nil(err)
+	assert.Equal(fmt.Sprintf("/mgmt/tm/%s/%s/~Common~dc1/stats", uriGtm, uriDatacenter), s.LastRequest.URL.Path)
+	assert.Equal("offline", stats.AvailabilityState)
+}
+
+func (s *GTMTestSuite) TestGetGTMPoolMemberStatsNotFound() {
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"code":404,"message":"Object not found","errorStack":[]}`))
+	}
+
+	stats, err := s.Client.GetGTMPoolMemberStats("/Common/app_pool", "/Common/someltm:/Common/gone_vs", ARecord)
+
+	assert.Nil(s.T(), err)
+	assert.Nil(s.T(), stats)
+}
+
 // ********************************************************************************************************************
 // **********************************************               *******************************************************
 // **********************************************   Test Data   *******************************************************
@@ -1,9 +1,18 @@
 package bigip
 
 import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"net"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
 // ServerSSLProfiles
@@ -70,63 +79,67 @@ type ClientSSLProfiles struct {
 // ClientSSLProfile contains information about each client-ssl profile. You can use all
 // of these fields when modifying a client-ssl profile.
 type ClientSSLProfile struct {
-	Name              string `json:"name,omitempty"`
-	Partition         string `json:"partition,omitempty"`
-	FullPath          string `json:"fullPath,omitempty"`
-	Generation        int    `json:"generation,omitempty"`
-	AlertTimeout      string `json:"alertTimeout,omitempty"`
-	AllowNonSsl       string `json:"allowNonSsl,omitempty"`
-	Authenticate      string `json:"authenticate,omitempty"`
-	AuthenticateDepth int    `json:"authenticateDepth,omitempty"`
-	CaFile            string `json:"caFile,omitempty"`
-	CacheSize         int    `json:"cacheSize,omitempty"`
-	CacheTimeout      int    `json:"cacheTimeout,omitempty"`
-	Cert              string `json:"cert,omitempty"`
-	CertKeyChain      []struct {
-		Name       string `json:"name,omitempty"`
-		Cert       string `json:"cert,omitempty"`
-		Chain      string `json:"chain,omitempty"`
-		Key        string `json:"key,omitempty"`
-		Passphrase string `json:"passphrase,omitempty"`
-	} `json:"certKeyChain,omitempty"`
-	CertExtensionIncludes           []string `json:"certExtensionIncludes,omitempty"`
-	CertLifespan                    int      `json:"certLifespan,omitempty"`
-	CertLookupByIpaddrPort          string   `json:"certLookupByIpaddrPort,omitempty"`
-	Chain                           string   `json:"chain,omitempty"`
-	Ciphers                         string   `json:"ciphers,omitempty"`
-	ClientCertCa                    string   `json:"clientCertCa,omitempty"`
-	CrlFile                         string   `json:"crlFile,omitempty"`
-	DefaultsFrom                    string   `json:"defaultsFrom,omitempty"`
-	ForwardProxyBypassDefaultAction string   `json:"forwardProxyBypassDefaultAction,omitempty"`
-	GenericAlert                    string   `json:"genericAlert,omitempty"`
-	HandshakeTimeout                string   `json:"handshakeTimeout,omitempty"`
-	InheritCertkeychain             string   `json:"inheritCertkeychain,omitempty"`
-	Key                             string   `json:"key,omitempty"`
-	ModSslMethods                   string   `json:"modSslMethods,omitempty"`
-	Mode                            string   `json:"mode,omitempty"`
-	TmOptions                       []string `json:"tmOptions,omitempty"`
-	Passphrase                      string   `json:"passphrase,omitempty"`
-	PeerCertMode                    string   `json:"peerCertMode,omitempty"`
-	ProxyCaCert                     string   `json:"proxyCaCert,omitempty"`
-	ProxyCaKey                      string   `json:"proxyCaKey,omitempty"`
-	ProxyCaPassphrase               string   `json:"proxyCaPassphrase,omitempty"`
-	ProxySsl                        string   `json:"proxySsl,omitempty"`
-	ProxySslPassthrough             string   `json:"proxySslPassthrough,omitempty"`
-	RenegotiatePeriod               string   `json:"renegotiatePeriod,omitempty"`
-	RenegotiateSize                 string   `json:"renegotiateSize,omitempty"`
-	Renegotiation                   string   `json:"renegotiation,omitempty"`
-	RetainCertificate               string   `json:"retainCertificate,omitempty"`
-	SecureRenegotiation             string   `json:"secureRenegotiation,omitempty"`
-	ServerName                      string   `json:"serverName,omitempty"`
-	SessionMirroring                string   `json:"sessionMirroring,omitempty"`
-	SessionTicket                   string   `json:"sessionTicket,omitempty"`
-	SniDefault                      string   `json:"sniDefault,omitempty"`
-	SniRequire                      string   `json:"sniRequire,omitempty"`
-	SslForwardProxy                 string   `json:"sslForwardProxy,omitempty"`
-	SslForwardProxyBypass           string   `json:"sslForwardProxyBypass,omitempty"`
-	SslSignHash                     string   `json:"sslSignHash,omitempty"`
-	StrictResume                    string   `json:"strictResume,omitempty"`
-	UncleanShutdown                 string   `json:"uncleanShutdown,omitempty"`
+	Name                            string         `json:"name,omitempty"`
+	Partition                       string         `json:"partition,omitempty"`
+	FullPath                        string         `json:"fullPath,omitempty"`
+	Generation                      int            `json:"generation,omitempty"`
+	AlertTimeout                    string         `json:"alertTimeout,omitempty"`
+	AllowNonSsl                     string         `json:"allowNonSsl,omitempty"`
+	Authenticate                    string         `json:"authenticate,omitempty"`
+	AuthenticateDepth               int            `json:"authenticateDepth,omitempty"`
+	CaFile                          string         `json:"caFile,omitempty"`
+	CacheSize                       int            `json:"cacheSize,omitempty"`
+	CacheTimeout                    int            `json:"cacheTimeout,omitempty"`
+	Cert                            string         `json:"cert,omitempty"`
+	CertKeyChain                    []CertKeyChain `json:"certKeyChain,omitempty"`
+	CertExtensionIncludes           []string       `json:"certExtensionIncludes,omitempty"`
+	CertLifespan                    int            `json:"certLifespan,omitempty"`
+	CertLookupByIpaddrPort          string         `json:"certLookupByIpaddrPort,omitempty"`
+	Chain                           string         `json:"chain,omitempty"`
+	Ciphers                         string         `json:"ciphers,omitempty"`
+	ClientCertCa                    string         `json:"clientCertCa,omitempty"`
+	CrlFile                         string         `json:"crlFile,omitempty"`
+	DefaultsFrom                    string         `json:"defaultsFrom,omitempty"`
+	ForwardProxyBypassDefaultAction string         `json:"forwardProxyBypassDefaultAction,omitempty"`
+	GenericAlert                    string         `json:"genericAlert,omitempty"`
+	HandshakeTimeout                string         `json:"handshakeTimeout,omitempty"`
+	InheritCertkeychain             string         `json:"inheritCertkeychain,omitempty"`
+	Key                             string         `json:"key,omitempty"`
+	ModSslMethods                   string         `json:"modSslMethods,omitempty"`
+	Mode                            string         `json:"mode,omitempty"`
+	TmOptions                       []string       `json:"tmOptions,omitempty"`
+	Passphrase                      string         `json:"passphrase,omitempty"`
+	PeerCertMode                    string         `json:"peerCertMode,omitempty"`
+	ProxyCaCert                     string         `json:"proxyCaCert,omitempty"`
+	ProxyCaKey                      string         `json:"proxyCaKey,omitempty"`
+	ProxyCaPassphrase               string         `json:"proxyCaPassphrase,omitempty"`
+	ProxySsl                        string         `json:"proxySsl,omitempty"`
+	ProxySslPassthrough             string         `json:"proxySslPassthrough,omitempty"`
+	RenegotiatePeriod               string         `json:"renegotiatePeriod,omitempty"`
+	RenegotiateSize                 string         `json:"renegotiateSize,omitempty"`
+	Renegotiation                   string         `json:"renegotiation,omitempty"`
+	RetainCertificate               string         `json:"retainCertificate,omitempty"`
+	SecureRenegotiation             string         `json:"secureRenegotiation,omitempty"`
+	ServerName                      string         `json:"serverName,omitempty"`
+	SessionMirroring                string         `json:"sessionMirroring,omitempty"`
+	SessionTicket                   string         `json:"sessionTicket,omitempty"`
+	SniDefault                      string         `json:"sniDefault,omitempty"`
+	SniRequire                      string         `json:"sniRequire,omitempty"`
+	SslForwardProxy                 string         `json:"sslForwardProxy,omitempty"`
+	SslForwardProxyBypass           string         `json:"sslForwardProxyBypass,omitempty"`
+	SslSignHash                     string         `json:"sslSignHash,omitempty"`
+	StrictResume                    string         `json:"strictResume,omitempty"`
+	UncleanShutdown                 string         `json:"uncleanShutdown,omitempty"`
+}
+
+// CertKeyChain is a certificate, its key and optional chain used by a client-ssl
+// profile. A profile can hold one of each key type, e.g. an RSA and an ECDSA certificate.
+type CertKeyChain struct {
+	Name       string `json:"name,omitempty"`
+	Cert       string `json:"cert,omitempty"`
+	Chain      string `json:"chain,omitempty"`
+	Key        string `json:"key,omitempty"`
+	Passphrase string `json:"passphrase,omitempty"`
 }
 
 // TcpProfiles contains a list of every tcp profile on the BIG-IP system.
@@ -406,6 +419,23 @@ func (p *DataGroup) UnmarshalJSON(b []byte) error {
 	return marshal(p, &dto)
 }
 
+// ExternalDataGroups contains a list of external data groups on the BIG-IP system.
+type ExternalDataGroups struct {
+	ExternalDataGroups []ExternalDataGroup `json:"items"`
+}
+
+// ExternalDataGroup contains information about each external data group. Its records are
+// stored in the data group file named by ExternalFileName.
+type ExternalDataGroup struct {
+	Name             string `json:"name,omitempty"`
+	Partition        string `json:"partition,omitempty"`
+	FullPath         string `json:"fullPath,omitempty"`
+	Generation       int    `json:"generation,omitempty"`
+	Description      string `json:"description,omitempty"`
+	ExternalFileName string `json:"externalFileName,omitempty"`
+	Type             string `json:"type,omitempty"`
+}
+
 // SnatPools contains a list of every snatpool on the BIG-IP system.
 type SnatPools struct {
 	SnatPools []SnatPool `json:"items"`
@@ -422,6 +452,87 @@ type SnatPool struct {
 	Members     []string `json:"members,omitempty"`
 }
 
+// Snats contains a list of every standalone SNAT on the BIG-IP system.
+type Snats struct {
+	Snats []Snat `json:"items"`
+}
+
+// Snat contains information about each individual standalone SNAT. Exactly one of
+// Translation, Snatpool or Automap selects how matching origins are translated.
+type Snat struct {
+	Name          string       `json:"name,omitempty"`
+	Partition     string       `json:"partition,omitempty"`
+	FullPath      string       `json:"fullPath,omitempty"`
+	Description   string       `json:"description,omitempty"`
+	Generation    int          `json:"generation,omitempty"`
+	AutoLasthop   string       `json:"autoLasthop,omitempty"`
+	Automap       bool         `json:"automap,omitempty"`
+	Mirror        string       `json:"mirror,omitempty"`
+	Origins       []SnatOrigin `json:"origins,omitempty"`
+	Snatpool      string       `json:"snatpool,omitempty"`
+	SourcePort    string       `json:"sourcePort,omitempty"`
+	Translation   string       `json:"translation,omitempty"`
+	Vlans         []string     `json:"vlans,omitempty"`
+	VlansEnabled  bool         `json:"vlansEnabled,omitempty"`
+	VlansDisabled bool         `json:"vlansDisabled,omitempty"`
+}
+
+// SnatOrigin is an address or network, in CIDR notation, whose connections a SNAT translates.
+type SnatOrigin struct {
+	Name string `json:"name"`
+}
+
+// SnatTranslations contains a list of every SNAT translation address on the BIG-IP system.
+type SnatTranslations struct {
+	SnatTranslations []SnatTranslation `json:"items"`
+}
+
+// SnatTranslation contains information about each individual SNAT translation address.
+type SnatTranslation struct {
+	Name            string `json:"name,omitempty"`
+	Partition       string `json:"partition,omitempty"`
+	FullPath        string `json:"fullPath,omitempty"`
+	Description     string `json:"description,omitempty"`
+	Generation      int    `json:"generation,omitempty"`
+	Address         string `json:"address,omitempty"`
+	ARP             string `json:"arp,omitempty"`
+	ConnectionLimit int    `json:"connectionLimit,omitempty"`
+	Enabled         bool   `json:"enabled,omitempty"`
+	Disabled        bool   `json:"disabled,omitempty"`
+	ICMPEcho        string `json:"icmpEcho,omitempty"`
+	IPIdleTimeout   string `json:"ipIdleTimeout,omitempty"`
+	TCPIdleTimeout  string `json:"tcpIdleTimeout,omitempty"`
+	TrafficGroup    string `json:"trafficGroup,omitempty"`
+	UDPIdleTimeout  string `json:"udpIdleTimeout,omitempty"`
+	Unit            int    `json:"unit,omitempty"`
+}
+
+// Nats contains a list of every NAT on the BIG-IP system.
+type Nats struct {
+	Nats []Nat `json:"items"`
+}
+
+// Nat contains information about each individual NAT, a one-to-one mapping between an
+// originating address and a translation address.
+type Nat struct {
+	Name               string   `json:"name,omitempty"`
+	Partition          string   `json:"partition,omitempty"`
+	FullPath           string   `json:"fullPath,omitempty"`
+	Description        string   `json:"description,omitempty"`
+	Generation         int      `json:"generation,omitempty"`
+	ARP                string   `json:"arp,omitempty"`
+	AutoLasthop        string   `json:"autoLasthop,omitempty"`
+	Enabled            bool     `json:"enabled,omitempty"`
+	Disabled           bool     `json:"disabled,omitempty"`
+	OriginatingAddress string   `json:"originatingAddress,omitempty"`
+	TrafficGroup       string   `json:"trafficGroup,omitempty"`
+	TranslationAddress string   `json:"translationAddress,omitempty"`
+	Unit               int      `json:"unit,omitempty"`
+	Vlans              []string `json:"vlans,omitempty"`
+	VlansEnabled       bool     `json:"vlansEnabled,omitempty"`
+	VlansDisabled      bool     `json:"vlansDisabled,omitempty"`
+}
+
 // Pools contains a list of pools on the BIG-IP system.
 type Pools struct {
 	Pools []Pool `json:"items"`
@@ -496,6 +607,157 @@ type PoolMember struct {
 	State           string `json:"state,omitempty"`
 }
 
+// PoolMemberStats contains the current connection counters and status of a pool member.
+type PoolMemberStats struct {
+	Address            string
+	Port               int64
+	CurrentConnections int64
+	MaxConnections     int64
+	TotalConnections   int64
+	CurrentSessions    int64
+	AvailabilityState  string
+	EnabledState       string
+	StatusReason       string
+}
+
+// DrainOptions controls how DrainPoolMember waits for a pool member to drain.
+type DrainOptions struct {
+	// Timeout is how long to wait for connections and persistence records to reach zero.
+	Timeout time.Duration
+	// PollInterval is how often the member stats are checked.
+	PollInterval time.Duration
+	// ForceOffline forces the member offline once it is drained or the timeout passes.
+	ForceOffline bool
+	// IgnorePersistence only waits for current connections to reach zero.
+	IgnorePersistence bool
+	// Progress, if set, is called after every poll.
+	Progress func(*DrainStatus)
+}
+
+// DrainStatus reports the progress of a pool member drain.
+type DrainStatus struct {
+	Pool               string
+	Member             string
+	CurrentConnections int64
+	PersistenceRecords int
+	Elapsed            time.Duration
+	Drained            bool
+}
+
+var defaultDrainOptions = &DrainOptions{
+	Timeout:      5 * time.Minute,
+	PollInterval: 5 * time.Second,
+}
+
+// PoolStats contains the current connection counters and status of a pool.
+type PoolStats struct {
+	ActiveMembers      int64
+	Members            int64
+	CurrentConnections int64
+	TotalConnections   int64
+	AvailabilityState  string
+	EnabledState       string
+	StatusReason       string
+}
+
+// Traffic shift methods used by ShiftTraffic.
+const (
+	// Swap the pool of the virtual server in a single step.
+	ShiftAtomic = "atomic"
+	// Add the new pool's members to the current pool and move traffic over in weighted
+	// steps by adjusting member ratios.
+	ShiftRatio = "ratio"
+	// Add the new pool's members to the current pool in a higher priority group so they
+	// take all traffic while available, falling back to the current members otherwise.
+	ShiftPriorityGroup = "priority-group"
+)
+
+// TrafficShiftOptions controls how ShiftTraffic moves a virtual server between pools.
+type TrafficShiftOptions struct {
+	// FromPool is the pool traffic is moved away from. Defaults to the current pool of the virtual server.
+	FromPool string
+	// ToPool is the pool traffic is moved to.
+	ToPool string
+	// Method is one of ShiftAtomic, ShiftRatio or ShiftPriorityGroup. Defaults to ShiftAtomic.
+	Method string
+	// Steps are the percentages of traffic sent to ToPool at each step when using ShiftRatio,
+	// strictly increasing and between 1 and 100. The final step always swaps the pool of the
+	// virtual server. Defaults to 10, 50, 100.
+	Steps []int
+	// StepInterval is how long to wait after each step before checking the availability of ToPool.
+	StepInterval time.Duration
+	// Progress, if set, is called after every step.
+	Progress func(*TrafficShiftStatus)
+}
+
+// TrafficShiftStatus reports the progress of a traffic shift.
+type TrafficShiftStatus struct {
+	VirtualServer string
+	FromPool      string
+	ToPool        string
+	Step          int
+	// Percent is the share of traffic sent to ToPool by member ratios. It is 0 while the
+	// new members take traffic through their priority group and 100 once the virtual
+	// server has been moved.
+	Percent    int
+	Available  bool
+	RolledBack bool
+}
+
+var defaultTrafficShiftOptions = &TrafficShiftOptions{
+	Method:       ShiftAtomic,
+	Steps:        []int{10, 50, 100},
+	StepInterval: 30 * time.Second,
+}
+
+// NodePoolMembership describes a pool member that references a node, along with the
+// member's session and state at the time it was read.
+type NodePoolMembership struct {
+	Pool    string `json:"pool"`
+	Member  string `json:"member"`
+	Address string `json:"address,omitempty"`
+	Session string `json:"session,omitempty"`
+	State   string `json:"state,omitempty"`
+}
+
+// NodeMaintenanceSnapshot records every pool membership of a node before it was put into
+// maintenance, so that the previous per-member state can be restored later.
+type NodeMaintenanceSnapshot struct {
+	Node    string               `json:"node"`
+	Members []NodePoolMembership `json:"members"`
+}
+
+// poolsWithMembers is used only when listing pools with their members expanded.
+type poolsWithMembers struct {
+	Pools []struct {
+		FullPath         string `json:"fullPath"`
+		MembersReference struct {
+			Members []PoolMember `json:"items"`
+		} `json:"membersReference"`
+	} `json:"items"`
+}
+
+// PersistenceFilter selects persistence records. Empty fields are ignored.
+type PersistenceFilter struct {
+	ClientAddress string
+	Key           string
+	Mode          string
+	NodeAddress   string
+	NodePort      string
+	Pool          string
+	Virtual       string
+}
+
+// PersistenceRecord contains information about each persistence record, as reported by
+// "show ltm persistence persist-records".
+type PersistenceRecord struct {
+	Mode    string
+	Value   string
+	Virtual string
+	Node    string
+	TMM     int
+}
+
 // VirtualServers contains a list of all virtual servers on the BIG-IP system.
 type VirtualServers struct {
 	VirtualServers []VirtualServer `json:"items"`
@@ -602,36 +864,50 @@ type Policies struct {
 	Policies []Policy `json:"items"`
 }
 
+// Policy contains information about each LTM policy. On BIG-IP 12.1 and later, Status
+// is "published" or "draft", and Legacy creates the policy directly, without a draft.
 type Policy struct {
-	Name      string
-	Partition string
-	FullPath  string
-	Controls  []string
-	Requires  []string
-	Strategy  string
-	Rules     []PolicyRule
+	Name        string
+	Partition   string
+	SubPath     string
+	FullPath    string
+	Description string
+	Controls    []string
+	Requires    []string
+	Strategy    string
+	Status      string
+	Legacy      bool
+	Rules       []PolicyRule
 }
 
 type policyDTO struct {
-	Name      string   `json:"name"`
-	Partition string   `json:"partition,omitempty"`
-	Controls  []string `json:"controls,omitempty"`
-	Requires  []string `json:"requires,omitempty"`
-	Strategy  string   `json:"strategy,omitempty"`
-	FullPath  string   `json:"fullPath,omitempty"`
-	Rules     struct {
+	Name        string   `json:"name"`
+	Partition   string   `json:"partition,omitempty"`
+	SubPath     string   `json:"subPath,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Controls    []string `json:"controls,omitempty"`
+	Requires    []string `json:"requires,omitempty"`
+	Strategy    string   `json:"strategy,omitempty"`
+	Status      string   `json:"status,omitempty"`
+	Legacy      bool     `json:"legacy,omitempty"`
+	FullPath    string   `json:"fullPath,omitempty"`
+	Rules       struct {
 		Items []PolicyRule `json:"items,omitempty"`
 	} `json:"rulesReference,omitempty"`
 }
 
 func (p *Policy) MarshalJSON() ([]byte, error) {
 	return json.Marshal(policyDTO{
-		Name:      p.Name,
-		Partition: p.Partition,
-		Controls:  p.Controls,
-		Requires:  p.Requires,
-		Strategy:  p.Strategy,
-		FullPath:  p.FullPath,
+		Name:        p.Name,
+		Partition:   p.Partition,
+		SubPath:     p.SubPath,
+		Description: p.Description,
+		Controls:    p.Controls,
+		Requires:    p.Requires,
+		Strategy:    p.Strategy,
+		Status:      p.Status,
+		Legacy:      p.Legacy,
+		FullPath:    p.FullPath,
 		Rules: struct {
 			Items []PolicyRule `json:"items,omitempty"`
 		}{Items: p.Rules},
@@ -647,9 +923,13 @@ func (p *Policy) UnmarshalJSON(b []byte) error {
 
 	p.Name = dto.Name
 	p.Partition = dto.Partition
+	p.SubPath = dto.SubPath
+	p.Description = dto.Description
 	p.Controls = dto.Controls
 	p.Requires = dto.Requires
 	p.Strategy = dto.Strategy
+	p.Status = dto.Status
+	p.Legacy = dto.Legacy
 	p.Rules = dto.Rules.Items
 	p.FullPath = dto.FullPath
 
@@ -973,40 +1253,130 @@ type Monitor struct {
 	MandatoryAttributes string
 	ChaseReferrals      string
 	Security            string
+	Adaptive            string
+	Count               string
+	Debug               string
+	FilterNeg           string
+	Mode                string
+	DNS                 *MonitorDNS
+	SNMPDCA             *MonitorSNMPDCA
+	TLS                 *MonitorTLS
+	RADIUS              *MonitorRADIUS
+	SIP                 *MonitorSIP
+	SMTP                *MonitorSMTP
+	External            *MonitorExternal
+}
+
+// MonitorDNS holds the settings specific to dns monitors.
+type MonitorDNS struct {
+	AcceptRcode    string `json:"acceptRcode,omitempty"`
+	AnswerContains string `json:"answerContains,omitempty"`
+	QueryName      string `json:"qname,omitempty"`
+	QueryType      string `json:"qtype,omitempty"`
+}
+
+// MonitorSNMPDCA holds the settings specific to snmp-dca monitors.
+type MonitorSNMPDCA struct {
+	AgentType         string `json:"agentType,omitempty"`
+	Community         string `json:"community,omitempty"`
+	Version           string `json:"version,omitempty"`
+	CPUCoefficient    string `json:"cpuCoefficient,omitempty"`
+	CPUThreshold      string `json:"cpuThreshold,omitempty"`
+	DiskCoefficient   string `json:"diskCoefficient,omitempty"`
+	DiskThreshold     string `json:"diskThreshold,omitempty"`
+	MemoryCoefficient string `json:"memoryCoefficient,omitempty"`
+	MemoryThreshold   string `json:"memoryThreshold,omitempty"`
+}
+
+// MonitorTLS holds the settings specific to https monitors, and sip monitors using TLS.
+type MonitorTLS struct {
+	Cert          string `json:"cert,omitempty"`
+	Key           string `json:"key,omitempty"`
+	Cipherlist    string `json:"cipherlist,omitempty"`
+	Compatibility string `json:"compatibility,omitempty"`
+}
+
+// MonitorRADIUS holds the settings specific to radius monitors.
+type MonitorRADIUS struct {
+	NASIPAddress string `json:"nasIpAddress,omitempty"`
+	Secret       string `json:"secret,omitempty"`
+}
+
+// MonitorSIP holds the settings specific to sip monitors.
+type MonitorSIP struct {
+	Headers string `json:"headers,omitempty"`
+	Request string `json:"request,omitempty"`
+}
+
+// MonitorSMTP holds the settings specific to smtp monitors.
+type MonitorSMTP struct {
+	Domain string `json:"domain,omitempty"`
+}
+
+// MonitorExternal holds the settings specific to external monitors.
+type MonitorExternal struct {
+	Args string `json:"args,omitempty"`
+	Run  string `json:"run,omitempty"`
+}
+
+// monitorJSON is the wire format of a Monitor: the common attributes and the settings of
+// its type, at the same level. The settings of every type are decoded, and those of types
+// with none of their attributes set stay nil.
+type monitorJSON struct {
+	monitorDTO
+	*MonitorDNS
+	*MonitorSNMPDCA
+	*MonitorTLS
+	*MonitorRADIUS
+	*MonitorSIP
+	*MonitorSMTP
+	*MonitorExternal
 }
 
 type monitorDTO struct {
-	Name                string `json:"name,omitempty"`
-	Partition           string `json:"partition,omitempty"`
-	FullPath            string `json:"fullPath,omitempty"`
-	Generation          int    `json:"generation,omitempty"`
-	ParentMonitor       string `json:"defaultsFrom,omitempty"`
-	Database            string `json:"database,omitempty"`
-	Description         string `json:"description,omitempty"`
-	Destination         string `json:"destination,omitempty"`
-	Interval            int    `json:"interval,omitempty"`
-	IPDSCP              int    `json:"ipDscp,omitempty"`
-	ManualResume        string `json:"manualResume,omitempty" bool:"enabled"`
-	MonitorType         string `json:"monitorType,omitempty"`
-	Password            string `json:"password,omitempty"`
-	ReceiveColumn       string `json:"recvColumn,omitempty"`
-	ReceiveRow          string `json:"recvRow,omitempty"`
-	ReceiveString       string `json:"recv,omitempty"`
-	ReceiveDisable      string `json:"recvDisable,omitempty"`
-	Reverse             string `json:"reverse,omitempty" bool:"enabled"`
-	ResponseTime        int    `json:"responseTime"`
-	RetryTime           int    `json:"retryTime"`
-	SendString          string `json:"send,omitempty"`
-	TimeUntilUp         int    `json:"timeUntilUp,omitempty"`
-	Timeout             int    `json:"timeout,omitempty"`
-	Transparent         string `json:"transparent,omitempty" bool:"enabled"`
-	UpInterval          int    `json:"upInterval,omitempty"`
-	Username            string `json:"username,omitempty"`
-	Filter              string `json:"filter,omitempty"`
-	Base                string `json:"base,omitempty"`
-	MandatoryAttributes string `json:"mandatoryAttributes,omitempty"`
-	ChaseReferrals      string `json:"chaseReferrals,omitempty"`
-	Security            string `json:"security,omitempty"`
+	Name                string           `json:"name,omitempty"`
+	Partition           string           `json:"partition,omitempty"`
+	FullPath            string           `json:"fullPath,omitempty"`
+	Generation          int              `json:"generation,omitempty"`
+	ParentMonitor       string           `json:"defaultsFrom,omitempty"`
+	Database            string           `json:"database,omitempty"`
+	Description         string           `json:"description,omitempty"`
+	Destination         string           `json:"destination,omitempty"`
+	Interval            int              `json:"interval,omitempty"`
+	IPDSCP              int              `json:"ipDscp,omitempty"`
+	ManualResume        string           `json:"manualResume,omitempty" bool:"enabled"`
+	MonitorType         string           `json:"monitorType,omitempty"`
+	Password            string           `json:"password,omitempty"`
+	ReceiveColumn       string           `json:"recvColumn,omitempty"`
+	ReceiveRow          string           `json:"recvRow,omitempty"`
+	ReceiveString       string           `json:"recv,omitempty"`
+	ReceiveDisable      string           `json:"recvDisable,omitempty"`
+	Reverse             string           `json:"reverse,omitempty" bool:"enabled"`
+	ResponseTime        int              `json:"responseTime"`
+	RetryTime           int              `json:"retryTime"`
+	SendString          string           `json:"send,omitempty"`
+	TimeUntilUp         int              `json:"timeUntilUp,omitempty"`
+	Timeout             int              `json:"timeout,omitempty"`
+	Transparent         string           `json:"transparent,omitempty" bool:"enabled"`
+	UpInterval          int              `json:"upInterval,omitempty"`
+	Username            string           `json:"username,omitempty"`
+	Filter              string           `json:"filter,omitempty"`
+	Base                string           `json:"base,omitempty"`
+	MandatoryAttributes string           `json:"mandatoryAttributes,omitempty"`
+	ChaseReferrals      string           `json:"chaseReferrals,omitempty"`
+	Security            string           `json:"security,omitempty"`
+	Adaptive            string           `json:"adaptive,omitempty"`
+	Count               string           `json:"count,omitempty"`
+	Debug               string           `json:"debug,omitempty"`
+	FilterNeg           string           `json:"filterNeg,omitempty"`
+	Mode                string           `json:"mode,omitempty"`
+	DNS                 *MonitorDNS      `json:"-"`
+	SNMPDCA             *MonitorSNMPDCA  `json:"-"`
+	TLS                 *MonitorTLS      `json:"-"`
+	RADIUS              *MonitorRADIUS   `json:"-"`
+	SIP                 *MonitorSIP      `json:"-"`
+	SMTP                *MonitorSMTP     `json:"-"`
+	External            *MonitorExternal `json:"-"`
 }
 
 func (p *Monitor) MarshalJSON() ([]byte, error) {
@@ -1015,16 +1385,23 @@ func (p *Monitor) MarshalJSON() ([]byte, error) {
 	if strings.Contains(dto.SendString, "\r\n") {
 		dto.SendString = strings.Replace(dto.SendString, "\r\n", "\\r\\n", -1)
 	}
-	return jsonMarshal(dto)
+	return jsonMarshal(monitorJSON{dto, dto.DNS, dto.SNMPDCA, dto.TLS, dto.RADIUS, dto.SIP, dto.SMTP, dto.External})
 }
 
 func (p *Monitor) UnmarshalJSON(b []byte) error {
-	var dto monitorDTO
-	err := json.Unmarshal(b, &dto)
+	var m monitorJSON
+	err := json.Unmarshal(b, &m)
 	if err != nil {
 		return err
 	}
-	return marshal(p, &dto)
+	m.DNS = m.MonitorDNS
+	m.SNMPDCA = m.MonitorSNMPDCA
+	m.TLS = m.MonitorTLS
+	m.RADIUS = m.MonitorRADIUS
+	m.SIP = m.MonitorSIP
+	m.SMTP = m.MonitorSMTP
+	m.External = m.MonitorExternal
+	return marshal(p, &m.monitorDTO)
 }
 
 type Profiles struct {
@@ -1101,6 +1478,228 @@ func (b *BigIP) ModifySnatPool(name string, config *SnatPool) error {
 	return b.put(config, uriLtm, uriSnatPool, name)
 }
 
+// Snats returns a list of standalone SNATs.
+func (b *BigIP) Snats() (*Snats, error) {
+	var snats Snats
+	err, _ := b.getForEntity(&snats, uriLtm, uriSnat)
+	if err != nil {
+		return nil, err
+	}
+
+	return &snats, nil
+}
+
+// CreateSnat adds a new standalone SNAT translating connections from the given origins
+// to the translation address. An empty translation uses automap instead.
+func (b *BigIP) CreateSnat(name, translation string, origins []string) error {
+	config := &Snat{
+		Name:        name,
+		Translation: translation,
+		Automap:     translation == "",
+	}
+	for _, o := range origins {
+		config.Origins = append(config.Origins, SnatOrigin{Name: o})
+	}
+
+	return b.post(config, uriLtm, uriSnat)
+}
+
+// AddSnat adds a new standalone SNAT by config to the BIG-IP system.
+func (b *BigIP) AddSnat(config *Snat) error {
+	return b.post(config, uriLtm, uriSnat)
+}
+
+// GetSnat retrieves a Snat by name. Returns nil if the SNAT does not exist
+func (b *BigIP) GetSnat(name string) (*Snat, error) {
+	var snat Snat
+	err, ok := b.getForEntity(&snat, uriLtm, uriSnat, name)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	return &snat, nil
+}
+
+// DeleteSnat removes a standalone SNAT.
+func (b *BigIP) DeleteSnat(name string) error {
+	return b.delete(uriLtm, uriSnat, name)
+}
+
+// ModifySnat allows you to change any attribute of a standalone SNAT. Fields that
+// can be modified are referenced in the Snat struct.
+func (b *BigIP) ModifySnat(name string, config *Snat) error {
+	return b.put(config, uriLtm, uriSnat, name)
+}
+
+// PatchSnat allows you to change any attribute of a standalone SNAT. Sets only the
+// attributes specified.
+func (b *BigIP) PatchSnat(name string, config *Snat) error {
+	return b.patch(config, uriLtm, uriSnat, name)
+}
+
+// SnatTranslations returns a list of SNAT translation addresses.
+func (b *BigIP) SnatTranslations() (*SnatTranslations, error) {
+	var translations SnatTranslations
+	err, _ := b.getForEntity(&translations, uriLtm, uriSnatTranslation)
+	if err != nil {
+		return nil, err
+	}
+
+	return &translations, nil
+}
+
+// CreateSnatTranslation adds a new SNAT translation address to the BIG-IP system.
+func (b *BigIP) CreateSnatTranslation(name, address, trafficGroup string) error {
+	config := &SnatTranslation{
+		Name:         name,
+		Address:      address,
+		TrafficGroup: trafficGroup,
+	}
+
+	return b.post(config, uriLtm, uriSnatTranslation)
+}
+
+// AddSnatTranslation adds a new SNAT translation address by config to the BIG-IP system.
+func (b *BigIP) AddSnatTranslation(config *SnatTranslation) error {
+	return b.post(config, uriLtm, uriSnatTranslation)
+}
+
+// GetSnatTranslation retrieves a SnatTranslation by name. Returns nil if the SNAT translation
+// does not exist
+func (b *BigIP) GetSnatTranslation(name string) (*SnatTranslation, error) {
+	var translation SnatTranslation
+	err, ok := b.getForEntity(&translation, uriLtm, uriSnatTranslation, name)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	return &translation, nil
+}
+
+// DeleteSnatTranslation removes a SNAT translation address.
+func (b *BigIP) DeleteSnatTranslation(name string) error {
+	return b.delete(uriLtm, uriSnatTranslation, name)
+}
+
+// ModifySnatTranslation allows you to change any attribute of a SNAT translation address.
+// Fields that can be modified are referenced in the SnatTranslation struct.
+func (b *BigIP) ModifySnatTranslation(name string, config *SnatTranslation) error {
+	return b.put(config, uriLtm, uriSnatTranslation, name)
+}
+
+// PatchSnatTranslation allows you to change any attribute of a SNAT translation address.
+// Sets only the attributes specified.
+func (b *BigIP) PatchSnatTranslation(name string, config *SnatTranslation) error {
+	return b.patch(config, uriLtm, uriSnatTranslation, name)
+}
+
+// SnatTranslationStatus changes the status of a SNAT translation address. <state> can be
+// either "enable" or "disable".
+func (b *BigIP) SnatTranslationStatus(name, state string) error {
+	config := &SnatTranslation{}
+	switch state {
+	case ENABLED:
+		config.Enabled = true
+	case DISABLED:
+		config.Disabled = true
+	default:
+		return fmt.Errorf("Unknown state %s, must be %s or %s", state, ENABLED, DISABLED)
+	}
+
+	return b.patch(config, uriLtm, uriSnatTranslation, name)
+}
+
+// Nats returns a list of NATs.
+func (b *BigIP) Nats() (*Nats, error) {
+	var nats Nats
+	err, _ := b.getForEntity(&nats, uriLtm, uriNat)
+	if err != nil {
+		return nil, err
+	}
+
+	return &nats, nil
+}
+
+// CreateNat adds a new NAT mapping the originating address to the translation address.
+func (b *BigIP) CreateNat(name, originatingAddress, translationAddress string) error {
+	config := &Nat{
+		Name:               name,
+		OriginatingAddress: originatingAddress,
+		TranslationAddress: translationAddress,
+	}
+
+	return b.post(config, uriLtm, uriNat)
+}
+
+// AddNat adds a new NAT by config to the BIG-IP system.
+func (b *BigIP) AddNat(config *Nat) error {
+	return b.post(config, uriLtm, uriNat)
+}
+
+// GetNat retrieves a Nat by name. Returns nil if the NAT does not exist
+func (b *BigIP) GetNat(name string) (*Nat, error) {
+	var nat Nat
+	err, ok := b.getForEntity(&nat, uriLtm, uriNat, name)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	return &nat, nil
+}
+
+// DeleteNat removes a NAT.
+func (b *BigIP) DeleteNat(name string) error {
+	return b.delete(uriLtm, uriNat, name)
+}
+
+// ModifyNat allows you to change any attribute of a NAT. Fields that can be modified
+// are referenced in the Nat struct.
+func (b *BigIP) ModifyNat(name string, config *Nat) error {
+	return b.put(config, uriLtm, uriNat, name)
+}
+
+// PatchNat allows you to change any attribute of a NAT. Sets only the attributes specified.
+func (b *BigIP) PatchNat(name string, config *Nat) error {
+	return b.patch(config, uriLtm, uriNat, name)
+}
+
+// NatStatus changes the status of a NAT. <state> can be either "enable" or "disable".
+func (b *BigIP) NatStatus(name, state string) error {
+	config := &Nat{}
+	switch state {
+	case ENABLED:
+		config.Enabled = true
+	case DISABLED:
+		config.Disabled = true
+	default:
+		return fmt.Errorf("Unknown state %s, must be %s or %s", state, ENABLED, DISABLED)
+	}
+
+	return b.patch(config, uriLtm, uriNat, name)
+}
+
+// VirtualServerSourceAddressTranslation sets how a virtual server translates client source
+// addresses. <translationType> can be "none", "automap" or "snat"; pool names the snatpool
+// and is only used with "snat".
+func (b *BigIP) VirtualServerSourceAddressTranslation(vs, translationType, pool string) error {
+	config := &VirtualServer{}
+	config.SourceAddressTranslation.Type = translationType
+	if translationType == "snat" {
+		config.SourceAddressTranslation.Pool = pool
+	}
+
+	return b.patch(config, uriLtm, uriVirtual, vs)
+}
+
 // ServerSSLProfiles returns a list of server-ssl profiles.
 func (b *BigIP) ServerSSLProfiles() (*ServerSSLProfiles, error) {
 	var serverSSLProfiles ServerSSLProfiles
@@ -1203,6 +1802,166 @@ func (b *BigIP) ModifyClientSSLProfile(name string, config *ClientSSLProfile) er
 	return b.patch(config, uriLtm, uriProfile, uriClientSSL, name)
 }
 
+// AddClientSSLCertKeyChain adds a certificate and key to a client-ssl profile, replacing
+// the entry of the same name. The certificate, key and chain must already be installed.
+// If entry.Name is empty, the certificate name is used.
+func (b *BigIP) AddClientSSLCertKeyChain(profile string, entry CertKeyChain) error {
+	if err := b.validateCertKeyChain(entry); err != nil {
+		return err
+	}
+	if entry.Name == "" {
+		entry.Name = strings.TrimSuffix(entry.Cert[strings.LastIndex(entry.Cert, "/")+1:], ".crt")
+	}
+
+	p, err := b.GetClientSSLProfile(profile)
+	if err != nil {
+		return err
+	}
+	if p == nil {
+		return fmt.Errorf("client-ssl profile %s does not exist", profile)
+	}
+
+	chain := []CertKeyChain{}
+	replaced := false
+	for _, c := range p.CertKeyChain {
+		if c.Name == entry.Name {
+			c = entry
+			replaced = true
+		}
+		chain = append(chain, c)
+	}
+	if !replaced {
+		chain = append(chain, entry)
+	}
+
+	return b.ModifyClientSSLProfile(profile, &ClientSSLProfile{CertKeyChain: chain})
+}
+
+// RemoveClientSSLCertKeyChain removes the named certificate and key from a client-ssl
+// profile. A profile must keep at least one entry.
+func (b *BigIP) RemoveClientSSLCertKeyChain(profile, name string) error {
+	p, err := b.GetClientSSLProfile(profile)
+	if err != nil {
+		return err
+	}
+	if p == nil {
+		return fmt.Errorf("client-ssl profile %s does not exist", profile)
+	}
+
+	chain := []CertKeyChain{}
+	for _, c := range p.CertKeyChain {
+		if c.Name != name {
+			chain = append(chain, c)
+		}
+	}
+	if len(chain) == len(p.CertKeyChain) {
+		return nil
+	}
+	if len(chain) == 0 {
+		return fmt.Errorf("cannot remove %s, the last certificate of client-ssl profile %s", name, profile)
+	}
+
+	return b.ModifyClientSSLProfile(profile, &ClientSSLProfile{CertKeyChain: chain})
+}
+
+// validateCertKeyChain checks that the certificate, key and chain of a cert-key-chain
+// entry are installed.
+func (b *BigIP) validateCertKeyChain(entry CertKeyChain) error {
+	if entry.Cert == "" || entry.Key == "" {
+		return fmt.Errorf("a cert-key-chain entry needs both a certificate and a key")
+	}
+	for _, cert := range []string{entry.Cert, entry.Chain} {
+		if cert == "" {
+			continue
+		}
+		c, err := b.GetCertificate(cert)
+		if err != nil {
+			return err
+		}
+		if c == nil {
+			return fmt.Errorf("certificate %s does not exist", cert)
+		}
+	}
+	k, err := b.GetKey(entry.Key)
+	if err != nil {
+		return err
+	}
+	if k == nil {
+		return fmt.Errorf("key %s does not exist", entry.Key)
+	}
+
+	return nil
+}
+
+// VirtualServerClientSSLProfiles returns the client-ssl profiles attached to a virtual server.
+func (b *BigIP) VirtualServerClientSSLProfiles(vs string) ([]ClientSSLProfile, error) {
+	profiles, err := b.VirtualServerProfiles(vs)
+	if err != nil {
+		return nil, err
+	}
+	if profiles == nil {
+		return nil, fmt.Errorf("virtual server %s does not exist", vs)
+	}
+	all, err := b.ClientSSLProfiles()
+	if err != nil {
+		return nil, err
+	}
+
+	attached := map[string]bool{}
+	for _, p := range profiles.Profiles {
+		attached[p.FullPath] = true
+	}
+	var clientSSL []ClientSSLProfile
+	for _, p := range all.ClientSSLProfiles {
+		if attached[p.FullPath] {
+			clientSSL = append(clientSSL, p)
+		}
+	}
+
+	return clientSSL, nil
+}
+
+// SetVirtualServerSNIDefault makes <profile> the default SNI profile among the client-ssl
+// profiles of a virtual server, used when the client sends no or an unknown server name.
+// If <require> is set, clients that do not send a server name are rejected. Every other
+// client-ssl profile on the virtual server must have a ServerName.
+func (b *BigIP) SetVirtualServerSNIDefault(vs, profile string, require bool) error {
+	profiles, err := b.VirtualServerClientSSLProfiles(vs)
+	if err != nil {
+		return err
+	}
+
+	var def *ClientSSLProfile
+	for i, p := range profiles {
+		if p.FullPath == profile || p.Name == profile {
+			def = &profiles[i]
+			continue
+		}
+		if p.ServerName == "" || p.ServerName == "none" {
+			return fmt.Errorf("client-ssl profile %s on %s needs a server name to be used with SNI", p.FullPath, vs)
+		}
+	}
+	if def == nil {
+		return fmt.Errorf("client-ssl profile %s is not attached to virtual server %s", profile, vs)
+	}
+
+	// Clear the other defaults first, the BIG-IP allows only one per virtual server.
+	for _, p := range profiles {
+		if p.FullPath == def.FullPath || (p.SniDefault != "true" && p.SniRequire != "true") {
+			continue
+		}
+		err := b.ModifyClientSSLProfile(p.FullPath, &ClientSSLProfile{SniDefault: "false", SniRequire: "false"})
+		if err != nil {
+			return err
+		}
+	}
+
+	return b.ModifyClientSSLProfile(def.FullPath, &ClientSSLProfile{
+		SniDefault: "true",
+		SniRequire: toBoolString(require, "true", "false"),
+	})
+}
+
 // TcpProfiles returns a list of Tcp profiles
 func (b *BigIP) TcpProfiles() (*TcpProfiles, error) {
 	var tcpProfiles TcpProfiles
@@ -1614,43 +2373,109 @@ func (b *BigIP) GetInternalDataGroupRecords(name string) (*[]DataGroupRecord, er
 	return &dataGroup.Records, nil
 }
 
-// Pools returns a list of pools.
-func (b *BigIP) Pools() (*Pools, error) {
-	var pools Pools
-	err, _ := b.getForEntity(&pools, uriLtm, uriPool)
-	if err != nil {
-		return nil, err
-	}
+// AddDataGroupRecords adds records to an internal data group. Records whose name is
+// already in the data group are left untouched.
+func (b *BigIP) AddDataGroupRecords(name string, records []DataGroupRecord) error {
+	return b.editRecords(b.dataGroupRecords(name), func(current map[string]string) (add, modify []tmshRecord, remove []string) {
+		for _, r := range records {
+			if _, ok := current[r.Name]; !ok {
+				add = append(add, tmshRecord{Name: r.Name, Value: r.Data})
+				current[r.Name] = r.Data
+			}
+		}
+		return add, nil, nil
+	})
+}
 
-	return &pools, nil
+// RemoveDataGroupRecords removes the named records from an internal data group. Names
+// that are not in the data group are ignored.
+func (b *BigIP) RemoveDataGroupRecords(name string, names []string) error {
+	return b.editRecords(b.dataGroupRecords(name), func(current map[string]string) (add, modify []tmshRecord, remove []string) {
+		for _, n := range names {
+			if _, ok := current[n]; ok {
+				remove = append(remove, n)
+				delete(current, n)
+			}
+		}
+		return nil, nil, remove
+	})
 }
 
-// PoolMembers returns a list of pool members for the given pool.
-func (b *BigIP) PoolMembers(name string) (*PoolMembers, error) {
-	var poolMembers PoolMembers
-	err, _ := b.getForEntity(&poolMembers, uriLtm, uriPool, name, uriPoolMember)
-	if err != nil {
-		return nil, err
-	}
+// UpsertDataGroupRecords adds records to an internal data group, replacing the data of
+// records that already exist.
+func (b *BigIP) UpsertDataGroupRecords(name string, records []DataGroupRecord) error {
+	return b.editRecords(b.dataGroupRecords(name), func(current map[string]string) (add, modify []tmshRecord, remove []string) {
+		for _, r := range records {
+			data, ok := current[r.Name]
+			switch {
+			case !ok:
+				add = append(add, tmshRecord{Name: r.Name, Value: r.Data})
+			case data != r.Data:
+				modify = append(modify, tmshRecord{Name: r.Name, Value: r.Data})
+			}
+			current[r.Name] = r.Data
+		}
+		return add, modify, nil
+	})
+}
 
-	return &poolMembers, nil
+// dataGroupRecords describes the records of an internal data group for editRecords.
+func (b *BigIP) dataGroupRecords(name string) *recordCollection {
+	return &recordCollection{
+		what: "internal data group " + name,
+		read: func() (int, []tmshRecord, error) {
+			dataGroup, err := b.GetInternalDataGroup(name)
+			if err != nil {
+				return 0, nil, err
+			}
+			if dataGroup == nil {
+				return 0, nil, fmt.Errorf("internal data group %s does not exist", name)
+			}
+			records := make([]tmshRecord, len(dataGroup.Records))
+			for i, r := range dataGroup.Records {
+				records[i] = tmshRecord{Name: r.Name, Value: r.Data}
+			}
+			return dataGroup.Generation, records, nil
+		},
+		format: func(r tmshRecord) string {
+			if r.Value == "" {
+				return strconv.Quote(r.Name) + " { }"
+			}
+			return strconv.Quote(r.Name) + " { data " + strconv.Quote(r.Value) + " }"
+		},
+		quote: strconv.Quote,
+		patch: func(option string) error {
+			return b.patch(struct{}{}, uriLtm, uriDatagroup, uriInternal, name, option)
+		},
+		replace: func(generation int, records []tmshRecord) error {
+			config := &DataGroup{
+				Generation: generation,
+				Records:    make([]DataGroupRecord, len(records)),
+			}
+			for i, r := range records {
+				config.Records[i] = DataGroupRecord{Name: r.Name, Data: r.Value}
+			}
+			return b.put(config, uriLtm, uriDatagroup, uriInternal, name)
+		},
+	}
 }
 
-// AddPoolMember adds a node/member to the given pool. <member> must be in the form
-// of <node>:<port>, i.e.: "web-server1:443".
-func (b *BigIP) AddPoolMember(pool, member string) error {
-	config := &poolMember{
-		Name: member,
+// ExternalDataGroups returns a list of external data groups.
+func (b *BigIP) ExternalDataGroups() (*ExternalDataGroups, error) {
+	var dataGroups ExternalDataGroups
+	err, _ := b.getForEntity(&dataGroups, uriLtm, uriDatagroup, uriExternal)
+	if err != nil {
+		return nil, err
 	}
 
-	return b.post(config, uriLtm, uriPool, pool, uriPoolMember)
+	return &dataGroups, nil
 }
 
-// GetPoolMember returns the details of a member in the specified pool.
-func (b *BigIP) GetPoolMember(pool string, member string) (*PoolMember, error) {
-	var poolMember PoolMember
-	err, ok := b.getForEntity(&poolMember, uriLtm, uriPool, pool, uriPoolMember, member)
-
+// GetExternalDataGroup retrieves an external data group by name. Returns nil if the data
+// group does not exist
+func (b *BigIP) GetExternalDataGroup(name string) (*ExternalDataGroup, error) {
+	var dataGroup ExternalDataGroup
+	err, ok := b.getForEntity(&dataGroup, uriLtm, uriDatagroup, uriExternal, name)
 	if err != nil {
 		return nil, err
 	}
@@ -1658,19 +2483,198 @@ func (b *BigIP) GetPoolMember(pool string, member string) (*PoolMember, error) {
 		return nil, nil
 	}
 
-	return &poolMember, nil
+	return &dataGroup, nil
 }
 
-// CreatePoolMember creates a pool member for the specified pool.
-func (b *BigIP) CreatePoolMember(pool string, config *PoolMember) error {
-	return b.post(config, uriLtm, uriPool, pool, uriPoolMember)
+// AddExternalDataGroup adds a new external data group by config, referencing an existing
+// data group file.
+func (b *BigIP) AddExternalDataGroup(config *ExternalDataGroup) error {
+	return b.post(config, uriLtm, uriDatagroup, uriExternal)
 }
 
-// ModifyPoolMember will update the configuration of a particular pool member.
-func (b *BigIP) ModifyPoolMember(pool string, config *PoolMember) error {
-	member := config.FullPath
-	// These fields are not used when modifying a pool member; so omit them.
-	config.Name = ""
+// ModifyExternalDataGroup allows you to change any attribute of an external data group,
+// such as pointing it at a different data group file.
+func (b *BigIP) ModifyExternalDataGroup(name string, config *ExternalDataGroup) error {
+	return b.put(config, uriLtm, uriDatagroup, uriExternal, name)
+}
+
+// DeleteExternalDataGroup removes an external data group. The data group file it
+// references is left in place.
+func (b *BigIP) DeleteExternalDataGroup(name string) error {
+	return b.delete(uriLtm, uriDatagroup, uriExternal, name)
+}
+
+// CreateExternalDataGroup uploads the records as a data group file and creates an external
+// data group of the same name referencing it; datatype must be one of "ip", "string", or
+// "integer". The uploaded file is removed afterwards, and the data group file is removed
+// again if the external data group cannot be created.
+func (b *BigIP) CreateExternalDataGroup(name, datatype string, records []DataGroupRecord) error {
+	sourcePath, cleanup, err := b.uploadDataGroupRecords(name, datatype, records)
+	if err != nil {
+		return err
+	}
+
+	file := &DataGroupFile{
+		Name:       name,
+		SourcePath: sourcePath,
+		Type:       datatype,
+	}
+	if err := b.AddDataGroupFile(file); err != nil {
+		return withCleanupErrors(err, cleanup())
+	}
+
+	err = b.AddExternalDataGroup(&ExternalDataGroup{
+		Name:             name,
+		ExternalFileName: name,
+	})
+	if err != nil {
+		return withCleanupErrors(err, b.DeleteDataGroupFile(name), cleanup())
+	}
+
+	return cleanup()
+}
+
+// ReplaceExternalDataGroupRecords replaces all the records of an external data group. The
+// new records are uploaded and re-imported into the data group file in a single update,
+// so the data group never sees a partial list.
+func (b *BigIP) ReplaceExternalDataGroupRecords(name string, records []DataGroupRecord) error {
+	dataGroup, err := b.GetExternalDataGroup(name)
+	if err != nil {
+		return err
+	}
+	if dataGroup == nil {
+		return fmt.Errorf("external data group %s does not exist", name)
+	}
+
+	file, err := b.GetDataGroupFile(dataGroup.ExternalFileName)
+	if err != nil {
+		return err
+	}
+	if file == nil {
+		return fmt.Errorf("data group file %s does not exist", dataGroup.ExternalFileName)
+	}
+
+	sourcePath, cleanup, err := b.uploadDataGroupRecords(file.Name, file.Type, records)
+	if err != nil {
+		return err
+	}
+
+	err = b.ModifyDataGroupFile(dataGroup.ExternalFileName, &DataGroupFile{SourcePath: sourcePath})
+	if err != nil {
+		return withCleanupErrors(err, cleanup())
+	}
+
+	return cleanup()
+}
+
+// uploadDataGroupRecords uploads the records in the data group file format and returns
+// the source path to import them from and a function that removes the uploaded file.
+func (b *BigIP) uploadDataGroupRecords(name, datatype string, records []DataGroupRecord) (string, func() error, error) {
+	data, err := formatDataGroupRecords(datatype, records)
+	if err != nil {
+		return "", nil, err
+	}
+
+	upload, err := b.UploadBytes(data, name[strings.LastIndex(name, "/")+1:])
+	if err != nil {
+		return "", nil, err
+	}
+
+	cleanup := func() error {
+		return b.RemoveFile(upload.LocalFilePath)
+	}
+	return "file:" + upload.LocalFilePath, cleanup, nil
+}
+
+// formatDataGroupRecords renders records in the data group file format, one
+// `key := "value",` line per record. Addresses are written as host or network entries.
+func formatDataGroupRecords(datatype string, records []DataGroupRecord) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, r := range records {
+		switch datatype {
+		case "ip":
+			if strings.Contains(r.Name, "/") {
+				buf.WriteString("network " + r.Name)
+			} else {
+				buf.WriteString("host " + r.Name)
+			}
+		case "integer":
+			buf.WriteString(r.Name)
+		case "string":
+			buf.WriteString(strconv.Quote(r.Name))
+		default:
+			return nil, fmt.Errorf("Unknown data group type %s, must be ip, string or integer", datatype)
+		}
+		if r.Data != "" {
+			buf.WriteString(" := " + strconv.Quote(r.Data))
+		}
+		buf.WriteString(",\n")
+	}
+	if buf.Len() == 0 {
+		// The upload API does not accept an empty file.
+		buf.WriteString("\n")
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Pools returns a list of pools.
+func (b *BigIP) Pools() (*Pools, error) {
+	var pools Pools
+	err, _ := b.getForEntity(&pools, uriLtm, uriPool)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pools, nil
+}
+
+// PoolMembers returns a list of pool members for the given pool.
+func (b *BigIP) PoolMembers(name string) (*PoolMembers, error) {
+	var poolMembers PoolMembers
+	err, _ := b.getForEntity(&poolMembers, uriLtm, uriPool, name, uriPoolMember)
+	if err != nil {
+		return nil, err
+	}
+
+	return &poolMembers, nil
+}
+
+// AddPoolMember adds a node/member to the given pool. <member> must be in the form
+// of <node>:<port>, i.e.: "web-server1:443".
+func (b *BigIP) AddPoolMember(pool, member string) error {
+	config := &poolMember{
+		Name: member,
+	}
+
+	return b.post(config, uriLtm, uriPool, pool, uriPoolMember)
+}
+
+// GetPoolMember returns the details of a member in the specified pool.
+func (b *BigIP) GetPoolMember(pool string, member string) (*PoolMember, error) {
+	var poolMember PoolMember
+	err, ok := b.getForEntity(&poolMember, uriLtm, uriPool, pool, uriPoolMember, member)
+
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	return &poolMember, nil
+}
+
+// CreatePoolMember creates a pool member for the specified pool.
+func (b *BigIP) CreatePoolMember(pool string, config *PoolMember) error {
+	return b.post(config, uriLtm, uriPool, pool, uriPoolMember)
+}
+
+// ModifyPoolMember will update the configuration of a particular pool member.
+func (b *BigIP) ModifyPoolMember(pool string, config *PoolMember) error {
+	member := config.FullPath
+	// These fields are not used when modifying a pool member; so omit them.
+	config.Name = ""
 	config.Partition = ""
 	config.FullPath = ""
 
@@ -1738,6 +2742,215 @@ func (b *BigIP) PoolMemberStatus(pool string, member string, state string, owner
 	return b.put(config, uriLtm, uriPool, pool, uriPoolMember, member)
 }
 
+// GetPoolMemberStats returns the current statistics of a member in the specified pool.
+// Returns nil if the pool member does not exist.
+func (b *BigIP) GetPoolMemberStats(pool, member string) (*PoolMemberStats, error) {
+	stats, err := b.getStats(uriLtm, uriPool, pool, uriPoolMember, member)
+	if err != nil {
+		return nil, err
+	}
+	if stats == nil {
+		return nil, nil
+	}
+
+	return &PoolMemberStats{
+		Address:            stats["addr"].Description,
+		Port:               stats["port"].Value,
+		CurrentConnections: stats["serverside.curConns"].Value,
+		MaxConnections:     stats["serverside.maxConns"].Value,
+		TotalConnections:   stats["serverside.totConns"].Value,
+		CurrentSessions:    stats["curSessions"].Value,
+		AvailabilityState:  stats["status.availabilityState"].Description,
+		EnabledState:       stats["status.enabledState"].Description,
+		StatusReason:       stats["status.statusReason"].Description,
+	}, nil
+}
+
+// DrainPoolMember disables a pool member so that it only accepts connections belonging
+// to existing persistence sessions, then waits until its current connections and
+// persistence records reach zero or opts.Timeout passes. If opts.ForceOffline is set,
+// the member is forced offline afterwards and a timeout is not reported as an error.
+// <member> must be in the form of <node>:<port>, i.e.: "web-server1:443".
+func (b *BigIP) DrainPoolMember(pool, member string, opts *DrainOptions) error {
+	if opts == nil {
+		opts = defaultDrainOptions
+	}
+	timeout := opts.Timeout
+	if timeout == 0 {
+		timeout = defaultDrainOptions.Timeout
+	}
+	interval := opts.PollInterval
+	if interval == 0 {
+		interval = defaultDrainOptions.PollInterval
+	}
+
+	pm, err := b.GetPoolMember(pool, member)
+	if err != nil {
+		return err
+	}
+	if pm == nil {
+		return fmt.Errorf("pool member %s does not exist in pool %s", member, pool)
+	}
+
+	if err := b.PoolMemberStatus(pool, member, "disable"); err != nil {
+		return err
+	}
+
+	start := time.Now()
+	deadline := start.Add(timeout)
+	status := &DrainStatus{Pool: pool, Member: member}
+	for {
+		stats, err := b.GetPoolMemberStats(pool, member)
+		if err != nil {
+			return err
+		}
+		if stats != nil {
+			status.CurrentConnections = stats.CurrentConnections
+		}
+
+		if !opts.IgnorePersistence {
+			_, port := splitPoolMemberName(pm.Name)
+			records, err := b.PersistenceRecords(&PersistenceFilter{
+				NodeAddress: pm.Address,
+				NodePort:    port,
+				Pool:        pool,
+			})
+			if err != nil {
+				return err
+			}
+			status.PersistenceRecords = len(records)
+		}
+
+		status.Elapsed = time.Since(start)
+		status.Drained = status.CurrentConnections == 0 && status.PersistenceRecords == 0
+		if opts.Progress != nil {
+			opts.Progress(status)
+		}
+
+		if status.Drained || !time.Now().Add(interval).Before(deadline) {
+			break
+		}
+		time.Sleep(interval)
+	}
+
+	if opts.ForceOffline {
+		return b.PoolMemberStatus(pool, member, "offline")
+	}
+	if !status.Drained {
+		return fmt.Errorf("Timed out after %s waiting for %s to drain", timeout, member)
+	}
+
+	return nil
+}
+
+// RestorePoolMember re-enables a pool member after it has been drained or forced offline.
+// <member> must be in the form of <node>:<port>, i.e.: "web-server1:443".
+func (b *BigIP) RestorePoolMember(pool, member string) error {
+	return b.PoolMemberStatus(pool, member, "enable")
+}
+
+// NodePoolMemberships returns every pool member, across all partitions, that references
+// the given node. <node> can be the full path of the node, i.e.: "/Common/web-server1",
+// its name, which is looked up in the Common partition, or its address.
+func (b *BigIP) NodePoolMemberships(node string) ([]NodePoolMembership, error) {
+	var pools poolsWithMembers
+	err, _ := b.getForEntity(&pools, uriLtm, uriPool, "?expandSubcollections=true")
+	if err != nil {
+		return nil, err
+	}
+
+	byAddress := net.ParseIP(strings.Split(node, "%")[0]) != nil
+	if !byAddress && !strings.HasPrefix(node, "/") {
+		node = "/Common/" + node
+	}
+
+	memberships := []NodePoolMembership{}
+	for _, p := range pools.Pools {
+		for _, m := range p.MembersReference.Members {
+			if byAddress {
+				if node != m.Address && node != strings.Split(m.Address, "%")[0] {
+					continue
+				}
+			} else if nodePath, _ := splitPoolMemberName(m.FullPath); node != nodePath {
+				continue
+			}
+			memberships = append(memberships, NodePoolMembership{
+				Pool:    p.FullPath,
+				Member:  m.FullPath,
+				Address: m.Address,
+				Session: m.Session,
+				State:   m.State,
+			})
+		}
+	}
+
+	return memberships, nil
+}
+
+// NodeMaintenance changes the status of every pool member that references the given node.
+// <state> can be either "disable" or "offline". The returned snapshot records the previous
+// state of each member and can be passed to RestoreNodeMaintenance. If changing a member
+// fails, the snapshot is returned along with the error so the change can be rolled back.
+func (b *BigIP) NodeMaintenance(node, state string) (*NodeMaintenanceSnapshot, error) {
+	if state != "disable" && state != "offline" {
+		return nil, fmt.Errorf("state must be either \"disable\" or \"offline\", got %q", state)
+	}
+
+	members, err := b.NodePoolMemberships(node)
+	if err != nil {
+		return nil, err
+	}
+	if len(members) == 0 {
+		return nil, fmt.Errorf("node %s is not a member of any pool", node)
+	}
+
+	snapshot := &NodeMaintenanceSnapshot{
+		Node:    node,
+		Members: members,
+	}
+	for _, m := range members {
+		if err := b.PoolMemberStatus(m.Pool, m.Member, state); err != nil {
+			return snapshot, err
+		}
+	}
+
+	return snapshot, nil
+}
+
+// RestoreNodeMaintenance restores every pool member in the snapshot to the status it had
+// before NodeMaintenance was called.
+func (b *BigIP) RestoreNodeMaintenance(snapshot *NodeMaintenanceSnapshot) error {
+	for _, m := range snapshot.Members {
+		state := "enable"
+		if m.Session == "user-disabled" {
+			state = "disable"
+			if m.State == "user-down" {
+				state = "offline"
+			}
+		}
+		if err := b.PoolMemberStatus(m.Pool, m.Member, state); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// splitPoolMemberName splits a pool member name into its node and port. IPv6 members
+// use a "." to separate the port, i.e.: "2001:db8::1.80".
+func splitPoolMemberName(name string) (string, string) {
+	sep := ":"
+	if strings.Count(name, ":") > 1 {
+		sep = "."
+	}
+	i := strings.LastIndex(name, sep)
+	if i < 0 {
+		return name, ""
+	}
+
+	return name[:i], name[i+1:]
+}
+
 // CreatePool adds a new pool to the BIG-IP system by name.
 func (b *BigIP) CreatePool(name string) error {
 	config := &Pool{
@@ -1777,80 +2990,451 @@ func (b *BigIP) ModifyPool(name string, config *Pool) error {
 	return b.put(config, uriLtm, uriPool, name)
 }
 
-// VirtualServers returns a list of virtual servers.
-func (b *BigIP) VirtualServers() (*VirtualServers, error) {
-	var vs VirtualServers
-	err, _ := b.getForEntity(&vs, uriLtm, uriVirtual)
-	if err != nil {
-		return nil, err
+func (f *PersistenceFilter) options() []string {
+	return []string{
+		"client-addr", f.ClientAddress,
+		"key", f.Key,
+		"mode", f.Mode,
+		"node-addr", f.NodeAddress,
+		"node-port", f.NodePort,
+		"pool", f.Pool,
+		"virtual", f.Virtual,
 	}
-
-	return &vs, nil
 }
 
-// CreateVirtualServer adds a new virtual server to the BIG-IP system. <mask> can either be
-// in CIDR notation or decimal, i.e.: "24" or "255.255.255.0". A CIDR mask of "0" is the same
-// as "0.0.0.0".
-func (b *BigIP) CreateVirtualServer(name, destination, mask, pool string, port int) error {
-	subnetMask := cidr[mask]
-
-	if strings.Contains(mask, ".") {
-		subnetMask = mask
+// PersistenceRecords returns the persistence records matching the filter. A nil filter
+// returns every record.
+func (b *BigIP) PersistenceRecords(filter *PersistenceFilter) ([]PersistenceRecord, error) {
+	if filter == nil {
+		filter = &PersistenceFilter{}
+	}
+	out, err := b.rawRequest("get", filter.options(), uriLtm, uriPersistence, uriPersistRecords)
+	if err != nil {
+		return nil, err
 	}
 
-	config := &VirtualServer{
-		Name:        name,
-		Destination: fmt.Sprintf("%s:%d", destination, port),
-		Mask:        subnetMask,
-		Pool:        pool,
+	records := []PersistenceRecord{}
+	for _, fields := range parseTmshRecords(out) {
+		if len(fields) < 4 {
+			continue
+		}
+		records = append(records, PersistenceRecord{
+			Mode:    fields[0],
+			Value:   fields[1],
+			Virtual: fields[2],
+			Node:    fields[3],
+			TMM:     parseTmshTMM(fields),
+		})
 	}
 
-	return b.post(config, uriLtm, uriVirtual)
+	return records, nil
 }
 
-// AddVirtualServer adds a new virtual server by config to the BIG-IP system.
-func (b *BigIP) AddVirtualServer(config *VirtualServer) error {
-	return b.post(config, uriLtm, uriVirtual)
+// DeletePersistenceRecords removes the persistence records matching the filter, i.e. every
+// record of a virtual server. At least one field of the filter must be set.
+func (b *BigIP) DeletePersistenceRecords(filter *PersistenceFilter) error {
+	if filter == nil || tmshOptions(filter.options()...) == "" {
+		return fmt.Errorf("a filter is required to delete persistence records")
+	}
+	_, err := b.rawRequest("delete", filter.options(), uriLtm, uriPersistence, uriPersistRecords)
+	return err
 }
 
-// GetVirtualServer retrieves a virtual server by name. Returns nil if the virtual server does not exist
-func (b *BigIP) GetVirtualServer(name string) (*VirtualServer, error) {
-	var vs VirtualServer
-	err, ok := b.getForEntity(&vs, uriLtm, uriVirtual, name)
+// GetPoolStats returns the current statistics of a pool. Returns nil if the pool does not exist.
+func (b *BigIP) GetPoolStats(name string) (*PoolStats, error) {
+	stats, err := b.getStats(uriLtm, uriPool, name)
 	if err != nil {
 		return nil, err
 	}
-	if !ok {
+	if stats == nil {
 		return nil, nil
 	}
 
-	profiles, err := b.VirtualServerProfiles(name)
-	if err != nil {
-		return nil, err
+	return &PoolStats{
+		ActiveMembers:      stats["activeMemberCnt"].Value,
+		Members:            stats["memberCnt"].Value,
+		CurrentConnections: stats["serverside.curConns"].Value,
+		TotalConnections:   stats["serverside.totConns"].Value,
+		AvailabilityState:  stats["status.availabilityState"].Description,
+		EnabledState:       stats["status.enabledState"].Description,
+		StatusReason:       stats["status.statusReason"].Description,
+	}, nil
+}
+
+// ShiftTraffic moves a virtual server from one pool to another, either atomically or in
+// weighted steps. After each step it waits opts.StepInterval and checks the availability
+// of the new pool; if the new pool is not available, every change is rolled back and an
+// error is returned. When using ShiftRatio or ShiftPriorityGroup, the members of the new
+// pool are temporarily added to the current pool, which is restored once the virtual
+// server has been moved.
+func (b *BigIP) ShiftTraffic(virtual string, opts *TrafficShiftOptions) error {
+	if opts == nil || opts.ToPool == "" {
+		return fmt.Errorf("a pool to shift traffic to is required")
+	}
+	method := opts.Method
+	if method == "" {
+		method = defaultTrafficShiftOptions.Method
+	}
+	steps := opts.Steps
+	if len(steps) == 0 {
+		steps = defaultTrafficShiftOptions.Steps
+	}
+	switch method {
+	case ShiftAtomic:
+		steps = []int{100}
+	case ShiftPriorityGroup:
+		steps = []int{0, 100}
+	}
+	if method == ShiftRatio {
+		for i, percent := range steps {
+			if percent <= 0 || percent > 100 {
+				return fmt.Errorf("invalid traffic shift step %d%%, steps must be between 1 and 100", percent)
+			}
+			if i > 0 && percent <= steps[i-1] {
+				return fmt.Errorf("invalid traffic shift step %d%%, steps must be strictly increasing", percent)
+			}
+		}
+	}
+	if steps[len(steps)-1] != 100 {
+		steps = append(steps, 100)
+	}
+	interval := opts.StepInterval
+	if interval == 0 {
+		interval = defaultTrafficShiftOptions.StepInterval
 	}
-	vs.Profiles = profiles.Profiles
 
-	policy_names, err := b.VirtualServerPolicyNames(name)
+	status := &TrafficShiftStatus{
+		VirtualServer: virtual,
+		FromPool:      opts.FromPool,
+		ToPool:        opts.ToPool,
+	}
+	if status.FromPool == "" {
+		var vs VirtualServer
+		err, ok := b.getForEntity(&vs, uriLtm, uriVirtual, virtual)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("virtual server %s does not exist", virtual)
+		}
+		status.FromPool = vs.Pool
+	}
+
+	available, err := b.poolAvailable(opts.ToPool)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	if !available {
+		return fmt.Errorf("pool %s is not available", opts.ToPool)
 	}
-	vs.Policies = policy_names
 
-	return &vs, nil
-}
+	var shift *poolShift
+	switch method {
+	case ShiftAtomic:
+	case ShiftRatio, ShiftPriorityGroup:
+		shift, err = b.newPoolShift(status.FromPool, opts.ToPool, method)
+		if err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown traffic shift method %q", method)
+	}
 
-// DeleteVirtualServer removes a virtual server.
-func (b *BigIP) DeleteVirtualServer(name string) error {
-	return b.delete(uriLtm, uriVirtual, name)
-}
+	swapped := false
+	rollback := func() error {
+		if swapped {
+			if err := b.patch(map[string]string{"pool": status.FromPool}, uriLtm, uriVirtual, virtual); err != nil {
+				return err
+			}
+		}
+		if shift != nil {
+			return shift.restore()
+		}
+		return nil
+	}
 
-// ModifyVirtualServer allows you to change any attribute of a virtual server. Fields that
-// can be modified are referenced in the VirtualServer struct. Set all the attributes.
-func (b *BigIP) ModifyVirtualServer(name string, config *VirtualServer) error {
-	return b.put(config, uriLtm, uriVirtual, name)
-}
+	for i, percent := range steps {
+		status.Step = i + 1
+		status.Percent = percent
 
-// PatchVirtualServer allows you to change any attribute of a virtual server. Fields that
+		if percent >= 100 {
+			err = b.patch(map[string]string{"pool": opts.ToPool}, uriLtm, uriVirtual, virtual)
+			swapped = err == nil
+		} else {
+			err = shift.apply(percent)
+		}
+		if err != nil {
+			if rbErr := rollback(); rbErr != nil {
+				return fmt.Errorf("%s (rollback failed: %s)", err, rbErr)
+			}
+			return err
+		}
+
+		time.Sleep(interval)
+		status.Available, err = b.poolAvailable(opts.ToPool)
+		if err == nil && !status.Available {
+			err = fmt.Errorf("pool %s went down at step %d, rolled back %s to pool %s", opts.ToPool, status.Step, virtual, status.FromPool)
+		}
+		if err != nil {
+			if rbErr := rollback(); rbErr != nil {
+				return fmt.Errorf("%s (rollback failed: %s)", err, rbErr)
+			}
+			status.RolledBack = true
+			if opts.Progress != nil {
+				opts.Progress(status)
+			}
+			return err
+		}
+
+		if opts.Progress != nil {
+			opts.Progress(status)
+		}
+	}
+
+	if shift != nil {
+		return shift.restore()
+	}
+
+	return nil
+}
+
+func (b *BigIP) poolAvailable(name string) (bool, error) {
+	stats, err := b.GetPoolStats(name)
+	if err != nil {
+		return false, err
+	}
+
+	return stats != nil && stats.AvailabilityState == "available", nil
+}
+
+// poolShift tracks the temporary changes made to a pool while shifting traffic to the
+// members of another pool, so that they can be undone.
+type poolShift struct {
+	b        *BigIP
+	pool     string
+	method   string
+	original *Pool
+	members  []PoolMember
+	added    []PoolMember
+	created  []string
+	applied  bool
+}
+
+func (b *BigIP) newPoolShift(from, to, method string) (*poolShift, error) {
+	pool, err := b.GetPool(from)
+	if err != nil {
+		return nil, err
+	}
+	if pool == nil {
+		return nil, fmt.Errorf("pool %s does not exist", from)
+	}
+	members, err := b.PoolMembers(from)
+	if err != nil {
+		return nil, err
+	}
+	added, err := b.PoolMembers(to)
+	if err != nil {
+		return nil, err
+	}
+	if len(members.PoolMembers) == 0 || len(added.PoolMembers) == 0 {
+		return nil, fmt.Errorf("pools %s and %s must both have members", from, to)
+	}
+
+	existing := make(map[string]bool)
+	for _, m := range members.PoolMembers {
+		existing[m.FullPath] = true
+	}
+	for _, m := range added.PoolMembers {
+		if existing[m.FullPath] {
+			return nil, fmt.Errorf("pool member %s is in both %s and %s", m.FullPath, from, to)
+		}
+	}
+
+	return &poolShift{
+		b:        b,
+		pool:     from,
+		method:   method,
+		original: pool,
+		members:  members.PoolMembers,
+		added:    added.PoolMembers,
+	}, nil
+}
+
+// apply sends <percent> of the traffic of the pool to the added members. The ratios are
+// scaled by the number of members on each side so that the split is per pool rather
+// than per member.
+func (s *poolShift) apply(percent int) error {
+	if !s.applied {
+		s.applied = true
+		priority := 0
+		config := map[string]interface{}{"loadBalancingMode": "ratio-member"}
+		if s.method == ShiftPriorityGroup {
+			for _, m := range s.members {
+				if m.PriorityGroup >= priority {
+					priority = m.PriorityGroup + 1
+				}
+			}
+			config = map[string]interface{}{"minActiveMembers": 1}
+		}
+		if err := s.b.patch(config, uriLtm, uriPool, s.pool); err != nil {
+			return err
+		}
+		for _, m := range s.added {
+			member := &PoolMember{Name: m.FullPath, Ratio: 1, PriorityGroup: priority}
+			if s.method == ShiftPriorityGroup {
+				member.Ratio = m.Ratio
+			}
+			if err := s.b.CreatePoolMember(s.pool, member); err != nil {
+				return err
+			}
+			s.created = append(s.created, m.FullPath)
+		}
+	}
+	if s.method != ShiftRatio {
+		return nil
+	}
+
+	oldRatio := (100 - percent) * len(s.added)
+	newRatio := percent * len(s.members)
+	d := gcd(oldRatio, newRatio)
+	for _, m := range s.members {
+		if err := s.b.patchPoolMemberWeight(s.pool, m.FullPath, oldRatio/d, m.PriorityGroup); err != nil {
+			return err
+		}
+	}
+	for _, m := range s.added {
+		if err := s.b.patchPoolMemberWeight(s.pool, m.FullPath, newRatio/d, 0); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// restore removes the added members and resets the pool to its original configuration.
+func (s *poolShift) restore() error {
+	if !s.applied {
+		return nil
+	}
+
+	for len(s.created) > 0 {
+		if err := s.b.DeletePoolMember(s.pool, s.created[0]); err != nil {
+			return err
+		}
+		s.created = s.created[1:]
+	}
+	if s.method == ShiftRatio {
+		for _, m := range s.members {
+			if err := s.b.patchPoolMemberWeight(s.pool, m.FullPath, m.Ratio, m.PriorityGroup); err != nil {
+				return err
+			}
+		}
+	}
+
+	config := map[string]interface{}{"minActiveMembers": s.original.MinActiveMembers}
+	if s.method == ShiftRatio {
+		config = map[string]interface{}{"loadBalancingMode": s.original.LoadBalancingMode}
+	}
+	if err := s.b.patch(config, uriLtm, uriPool, s.pool); err != nil {
+		return err
+	}
+	s.applied = false
+
+	return nil
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// patchPoolMemberWeight sets the ratio and priority group of a pool member, including
+// zero values which PatchPoolMember would omit.
+func (b *BigIP) patchPoolMemberWeight(pool, member string, ratio, priorityGroup int) error {
+	config := map[string]interface{}{
+		"ratio":         ratio,
+		"priorityGroup": priorityGroup,
+	}
+
+	return b.patch(config, uriLtm, uriPool, pool, uriPoolMember, member)
+}
+
+// VirtualServers returns a list of virtual servers.
+func (b *BigIP) VirtualServers() (*VirtualServers, error) {
+	var vs VirtualServers
+	err, _ := b.getForEntity(&vs, uriLtm, uriVirtual)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vs, nil
+}
+
+// CreateVirtualServer adds a new virtual server to the BIG-IP system. <mask> can either be
+// in CIDR notation or decimal, i.e.: "24" or "255.255.255.0". A CIDR mask of "0" is the same
+// as "0.0.0.0".
+func (b *BigIP) CreateVirtualServer(name, destination, mask, pool string, port int) error {
+	subnetMask := cidr[mask]
+
+	if strings.Contains(mask, ".") {
+		subnetMask = mask
+	}
+
+	config := &VirtualServer{
+		Name:        name,
+		Destination: fmt.Sprintf("%s:%d", destination, port),
+		Mask:        subnetMask,
+		Pool:        pool,
+	}
+
+	return b.post(config, uriLtm, uriVirtual)
+}
+
+// AddVirtualServer adds a new virtual server by config to the BIG-IP system.
+func (b *BigIP) AddVirtualServer(config *VirtualServer) error {
+	return b.post(config, uriLtm, uriVirtual)
+}
+
+// GetVirtualServer retrieves a virtual server by name. Returns nil if the virtual server does not exist
+func (b *BigIP) GetVirtualServer(name string) (*VirtualServer, error) {
+	var vs VirtualServer
+	err, ok := b.getForEntity(&vs, uriLtm, uriVirtual, name)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	profiles, err := b.VirtualServerProfiles(name)
+	if err != nil {
+		return nil, err
+	}
+	vs.Profiles = profiles.Profiles
+
+	policy_names, err := b.VirtualServerPolicyNames(name)
+	if err != nil {
+		return nil, err
+	}
+	vs.Policies = policy_names
+
+	return &vs, nil
+}
+
+// DeleteVirtualServer removes a virtual server.
+func (b *BigIP) DeleteVirtualServer(name string) error {
+	return b.delete(uriLtm, uriVirtual, name)
+}
+
+// ModifyVirtualServer allows you to change any attribute of a virtual server. Fields that
+// can be modified are referenced in the VirtualServer struct. Set all the attributes.
+func (b *BigIP) ModifyVirtualServer(name string, config *VirtualServer) error {
+	return b.put(config, uriLtm, uriVirtual, name)
+}
+
+// PatchVirtualServer allows you to change any attribute of a virtual server. Fields that
 // can be modified are referenced in the VirtualServer struct. Sets only the attributes specified.
 func (b *BigIP) PatchVirtualServer(name string, config *VirtualServer) error {
 	return b.patch(config, uriLtm, uriVirtual, name)
@@ -1933,38 +3517,262 @@ func (b *BigIP) DeleteVirtualAddress(vaddr string) error {
 	return b.delete(uriLtm, uriVirtualAddress, vaddr)
 }
 
-// Monitors returns a list of all HTTP, HTTPS, Gateway ICMP, ICMP, and Tcp monitors.
+// MonitorTypes returns the monitor types available on the BIG-IP system, such as "http",
+// "dns" or "tcp-half-open", as listed by ltm/monitor.
+func (b *BigIP) MonitorTypes() ([]string, error) {
+	var collection struct {
+		Items []struct {
+			Reference struct {
+				Link string `json:"link"`
+			} `json:"reference"`
+		} `json:"items"`
+	}
+	err, _ := b.getForEntity(&collection, uriLtm, uriMonitor)
+	if err != nil {
+		return nil, err
+	}
+
+	var types []string
+	for _, item := range collection.Items {
+		link := item.Reference.Link
+		if i := strings.Index(link, "?"); i >= 0 {
+			link = link[:i]
+		}
+		if i := strings.LastIndex(link, "/"+uriMonitor+"/"); i >= 0 {
+			types = append(types, link[i+len(uriMonitor)+2:])
+		}
+	}
+
+	return types, nil
+}
+
+// maxMonitorTypeRequests is how many monitor types Monitors fetches at once.
+const maxMonitorTypeRequests = 4
+
+// Monitors returns a list of all monitors of every type available on the BIG-IP system.
+// Up to maxMonitorTypeRequests types are fetched concurrently; MonitorType is set on every
+// returned monitor. If any type fails, the errors of all failed types are returned.
 func (b *BigIP) Monitors() ([]Monitor, error) {
+	monitorTypes, err := b.MonitorTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([][]Monitor, len(monitorTypes))
+	errs := make([]error, len(monitorTypes))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < maxMonitorTypeRequests && w < len(monitorTypes); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				results[i], errs[i] = b.MonitorsOfType(monitorTypes[i])
+			}
+		}()
+	}
+	for i := range monitorTypes {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
 	var monitors []Monitor
-	monitorUris := []string{
-		"gateway-icmp",
-		"http",
-		"https",
-		"icmp",
-		"inband",
-		"mysql",
-		"postgresql",
-		"tcp",
-		"udp",
-	}
-
-	for _, name := range monitorUris {
-		var m Monitors
-		err, _ := b.getForEntity(&m, uriLtm, uriMonitor, name)
+	var failed []string
+	for i, name := range monitorTypes {
+		if errs[i] != nil {
+			failed = append(failed, fmt.Sprintf("%s: %s", name, errs[i]))
+			continue
+		}
+		monitors = append(monitors, results[i]...)
+	}
+	if len(failed) > 0 {
+		return nil, fmt.Errorf("failed to get monitors: %s", strings.Join(failed, "; "))
+	}
+
+	return monitors, nil
+}
+
+// MonitorsOfType returns a list of all monitors of the given <monitorType>, e.g. "dns".
+func (b *BigIP) MonitorsOfType(monitorType string) ([]Monitor, error) {
+	var m Monitors
+	err, _ := b.getForEntity(&m, uriLtm, uriMonitor, monitorType)
+	if err != nil {
+		return nil, err
+	}
+	for i := range m.Monitors {
+		m.Monitors[i].MonitorType = monitorType
+	}
+
+	return m.Monitors, nil
+}
+
+// ExternalMonitorConfig describes an external monitor and the script it runs, for use
+// with DeployExternalMonitor.
+type ExternalMonitorConfig struct {
+	Name          string
+	ParentMonitor string
+	Script        []byte
+	Args          string
+	Interval      int
+	Timeout       int
+	Variables     map[string]string
+}
+
+// externalMonitorScriptPrefix marks the external monitor files imported by
+// DeployExternalMonitor, the only ones it removes.
+const externalMonitorScriptPrefix = "go-bigip-"
+
+// DeployExternalMonitor uploads the script and creates the external monitor running it,
+// or updates the monitor if it already exists. Each version of the script is imported
+// under its own name, "go-bigip-<name>-<checksum>", so the monitor switches to the new
+// script in one update; older versions no longer run by any external monitor are then
+// removed. Files not named this way are never removed. Variables not in
+// config.Variables are removed from the monitor.
+func (b *BigIP) DeployExternalMonitor(config *ExternalMonitorConfig) error {
+	base := config.Name[strings.LastIndex(config.Name, "/")+1:]
+	sum := sha1.Sum(config.Script)
+	script := fmt.Sprintf("%s%s-%x", externalMonitorScriptPrefix, base, sum[:4])
+
+	file, err := b.GetExternalMonitorFile(script)
+	if err != nil {
+		return err
+	}
+	if file == nil {
+		upload, err := b.UploadBytes(config.Script, script)
 		if err != nil {
-			return nil, err
+			return err
+		}
+		err = b.AddExternalMonitorFile(&ExternalMonitorFile{
+			Name:       script,
+			SourcePath: "file:" + upload.LocalFilePath,
+		})
+		if err != nil {
+			return withCleanupErrors(err, b.RemoveFile(upload.LocalFilePath))
 		}
-		for _, monitor := range m.Monitors {
-			monitor.MonitorType = name
-			monitors = append(monitors, monitor)
+		if err := b.RemoveFile(upload.LocalFilePath); err != nil {
+			return err
 		}
 	}
 
-	return monitors, nil
+	monitor := &Monitor{
+		ParentMonitor: config.ParentMonitor,
+		Interval:      config.Interval,
+		Timeout:       config.Timeout,
+		External:      &MonitorExternal{Run: script, Args: config.Args},
+	}
+	existing, err := b.GetMonitor(config.Name, uriExternal)
+	if err != nil {
+		return err
+	}
+	var variables map[string]string
+	if existing == nil {
+		monitor.Name = config.Name
+		err = b.AddMonitor(monitor, uriExternal)
+	} else {
+		if variables, err = b.ExternalMonitorVariables(config.Name); err == nil {
+			err = b.PatchMonitor(config.Name, uriExternal, monitor)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	var options []string
+	for _, k := range sortedKeys(variables) {
+		if _, ok := config.Variables[k]; !ok {
+			options = append(options, "user-defined", k+" none")
+		}
+	}
+	for _, k := range sortedKeys(config.Variables) {
+		if v, ok := variables[k]; !ok || v != config.Variables[k] {
+			options = append(options, "user-defined", k+" "+strconv.Quote(config.Variables[k]))
+		}
+	}
+	if len(options) > 0 {
+		err = b.patch(struct{}{}, uriLtm, uriMonitor, uriExternal, config.Name, tmshOptions(options...))
+		if err != nil {
+			return err
+		}
+	}
+
+	return b.cleanupExternalMonitorFiles(base, script)
+}
+
+// ExternalMonitorVariables returns the user defined variables an external monitor passes
+// to its script.
+func (b *BigIP) ExternalMonitorVariables(name string) (map[string]string, error) {
+	var raw struct {
+		APIRawValues map[string]string `json:"apiRawValues"`
+	}
+	err, _ := b.getForEntity(&raw, uriLtm, uriMonitor, uriExternal, name)
+	if err != nil {
+		return nil, err
+	}
+
+	variables := map[string]string{}
+	for k, v := range raw.APIRawValues {
+		if strings.HasPrefix(k, "userDefined ") {
+			variables[strings.TrimPrefix(k, "userDefined ")] = v
+		}
+	}
+
+	return variables, nil
+}
+
+// cleanupExternalMonitorFiles removes the earlier versions of a script imported by
+// DeployExternalMonitor that are no longer run by any external monitor.
+func (b *BigIP) cleanupExternalMonitorFiles(base, current string) error {
+	files, err := b.ExternalMonitorFiles()
+	if err != nil {
+		return err
+	}
+	monitors, err := b.MonitorsOfType(uriExternal)
+	if err != nil {
+		return err
+	}
+
+	inUse := map[string]bool{}
+	for _, m := range monitors {
+		if m.External != nil {
+			inUse[m.External.Run[strings.LastIndex(m.External.Run, "/")+1:]] = true
+		}
+	}
+
+	for _, f := range files.ExternalMonitorFiles {
+		if f.Name == current || inUse[f.Name] || !isScriptVersion(f.Name, base) {
+			continue
+		}
+		if err := b.DeleteExternalMonitorFile(f.FullPath); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isScriptVersion reports whether name is "go-bigip-<base>-<checksum>" as named by
+// DeployExternalMonitor.
+func isScriptVersion(name, base string) bool {
+	prefix := externalMonitorScriptPrefix + base + "-"
+	if !strings.HasPrefix(name, prefix) {
+		return false
+	}
+	_, err := hex.DecodeString(strings.TrimPrefix(name, prefix))
+	return err == nil && len(name) == len(prefix)+8
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
 }
 
-// CreateMonitor adds a new monitor to the BIG-IP system. <monitorType> must be one of "http", "https",
-// "icmp", "gateway icmp", "inband", "postgresql", "mysql", "udp" or "tcp".
+// CreateMonitor adds a new monitor to the BIG-IP system. <monitorType> is any of the types
+// returned by MonitorTypes, e.g. "http", "https", "icmp", "gateway-icmp", "dns" or "tcp".
 func (b *BigIP) CreateMonitor(name, parent string, interval, timeout int, send, receive, monitorType string) error {
 	config := &Monitor{
 		Name:          name,
@@ -2007,8 +3815,8 @@ func (b *BigIP) DeleteMonitor(name, monitorType string) error {
 	return b.delete(uriLtm, uriMonitor, monitorType, name)
 }
 
-// ModifyMonitor allows you to change any attribute of a monitor. <monitorType> must
-// be one of "http", "https", "icmp", "inband", "gateway icmp", "postgresql", "mysql", "udp" or "tcp".
+// ModifyMonitor allows you to change any attribute of a monitor. <monitorType> is any of
+// the types returned by MonitorTypes.
 // Fields that can be modified are referenced in the Monitor struct.
 func (b *BigIP) ModifyMonitor(name, monitorType string, config *Monitor) error {
 	if strings.Contains(config.ParentMonitor, "gateway") {
@@ -2076,29 +3884,185 @@ func (b *BigIP) ModifyIRule(name string, irule *IRule) error {
 	return b.put(irule, uriLtm, uriIRule, name)
 }
 
-func (b *BigIP) Policies() (*Policies, error) {
-	var p Policies
-	err, _ := b.getForEntity(&p, uriLtm, uriPolicy, policyVersionSuffix)
-	if err != nil {
-		return nil, err
+// IRuleDiagnostic is a problem the BIG-IP found when parsing an iRule.
+type IRuleDiagnostic struct {
+	Line     int
+	Severity string
+	Message  string
+}
+
+// IRuleSyntaxError is returned by ReplaceIRuleSafely when the BIG-IP rejects the new
+// iRule.
+type IRuleSyntaxError struct {
+	Name        string
+	Diagnostics []IRuleDiagnostic
+}
+
+func (e *IRuleSyntaxError) Error() string {
+	msgs := make([]string, len(e.Diagnostics))
+	for i, d := range e.Diagnostics {
+		msgs[i] = fmt.Sprintf("line %d: %s", d.Line, d.Message)
 	}
+	return fmt.Sprintf("iRule %s is invalid: %s", e.Name, strings.Join(msgs, "; "))
+}
 
-	return &p, nil
+var iruleDiagnostic = regexp.MustCompile(`(?m):(\d+): (error|warning): (.*)$`)
+
+// ValidateIRule checks the syntax of an iRule by creating it under a scratch name in the
+// Common partition, and returns the diagnostics the BIG-IP reported. The scratch iRule is
+// removed again. A valid iRule returns no diagnostics.
+func (b *BigIP) ValidateIRule(rule string) ([]IRuleDiagnostic, error) {
+	return b.validateIRule("Common", rule)
 }
 
-// Load a fully policy definition. Policies seem to be best dealt with as one big entity.
-func (b *BigIP) GetPolicy(name string) (*Policy, error) {
-	var p Policy
-	err, ok := b.getForEntity(&p, uriLtm, uriPolicy, name, policyVersionSuffix)
+func (b *BigIP) validateIRule(partition, rule string) ([]IRuleDiagnostic, error) {
+	scratch := &IRule{
+		Name:      fmt.Sprintf("__validate_irule_%d", time.Now().UnixNano()),
+		Partition: partition,
+		Rule:      rule,
+	}
+	err := b.post(scratch, uriLtm, uriIRule)
 	if err != nil {
-		return nil, err
+		diagnostics := parseIRuleDiagnostics(err.Error())
+		if len(diagnostics) == 0 {
+			return nil, err
+		}
+		return diagnostics, nil
 	}
-	if !ok {
+
+	return nil, b.DeleteIRule(fmt.Sprintf("/%s/%s", partition, scratch.Name))
+}
+
+func parseIRuleDiagnostics(message string) []IRuleDiagnostic {
+	var diagnostics []IRuleDiagnostic
+	for _, m := range iruleDiagnostic.FindAllStringSubmatch(message, -1) {
+		line, _ := strconv.Atoi(m[1])
+		text := m[3]
+		// Messages look like "[undefined procedure: foo][foo]"; keep the first part.
+		if strings.HasPrefix(text, "[") {
+			if i := strings.Index(text, "]"); i > 0 {
+				text = text[1:i]
+			}
+		}
+		diagnostics = append(diagnostics, IRuleDiagnostic{
+			Line:     line,
+			Severity: m[2],
+			Message:  text,
+		})
+	}
+
+	return diagnostics
+}
+
+// ReplaceIRuleSafely validates the new iRule and only then replaces the existing one, or
+// creates it if it does not exist. It returns the full paths of the virtual servers that
+// use the iRule. If the iRule is invalid, the error is an *IRuleSyntaxError and nothing
+// is changed.
+func (b *BigIP) ReplaceIRuleSafely(name, rule string) ([]string, error) {
+	partition := "Common"
+	if strings.HasPrefix(name, "/") {
+		partition = strings.SplitN(name[1:], "/", 2)[0]
+	}
+
+	existing, err := b.IRule(name)
+	if err != nil {
+		return nil, err
+	}
+	fullPath := name
+	if existing != nil {
+		fullPath = existing.FullPath
+	} else if !strings.HasPrefix(name, "/") {
+		fullPath = fmt.Sprintf("/%s/%s", partition, name)
+	}
+
+	var virtuals []string
+	vs, err := b.VirtualServers()
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range vs.VirtualServers {
+		for _, r := range v.Rules {
+			if r == fullPath {
+				virtuals = append(virtuals, v.FullPath)
+				break
+			}
+		}
+	}
+
+	diagnostics, err := b.validateIRule(partition, rule)
+	if err != nil {
+		return virtuals, err
+	}
+	if len(diagnostics) > 0 {
+		return virtuals, &IRuleSyntaxError{Name: name, Diagnostics: diagnostics}
+	}
+
+	if existing == nil {
+		return virtuals, b.CreateIRule(name, rule)
+	}
+	return virtuals, b.ModifyIRule(name, &IRule{Rule: rule})
+}
+
+// policyDrafts reports whether policies on the BIG-IP use the draft-publish workflow
+// introduced in 12.1.
+func (b *BigIP) policyDrafts() (bool, error) {
+	version, err := b.TMOSVersion()
+	if err != nil {
+		return false, err
+	}
+	return versionAtLeast(version, 12, 1), nil
+}
+
+// policyQuery returns the query to pin policy requests to the 11.5.1 API on versions
+// without drafts.
+func (b *BigIP) policyQuery() (string, error) {
+	drafts, err := b.policyDrafts()
+	if err != nil || drafts {
+		return "", err
+	}
+	return policyVersionSuffix, nil
+}
+
+// policyPath appends the version query, if any, to a policy path.
+func policyPath(query string, path ...string) []string {
+	if query == "" {
+		return path
+	}
+	return append(path, query)
+}
+
+// Policies returns a list of policies. On BIG-IP 12.1 and later, drafts are included.
+func (b *BigIP) Policies() (*Policies, error) {
+	query, err := b.policyQuery()
+	if err != nil {
+		return nil, err
+	}
+	var p Policies
+	err, _ = b.getForEntity(&p, policyPath(query, uriLtm, uriPolicy)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+// Load a fully policy definition. Policies seem to be best dealt with as one big entity.
+func (b *BigIP) GetPolicy(name string) (*Policy, error) {
+	query, err := b.policyQuery()
+	if err != nil {
+		return nil, err
+	}
+	var p Policy
+	err, ok := b.getForEntity(&p, policyPath(query, uriLtm, uriPolicy, name)...)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
 		return nil, nil
 	}
 
 	var rules PolicyRules
-	err, _ = b.getForEntity(&rules, uriLtm, uriPolicy, name, "rules", policyVersionSuffix)
+	err, _ = b.getForEntity(&rules, policyPath(query, uriLtm, uriPolicy, name, "rules")...)
 	if err != nil {
 		return nil, err
 	}
@@ -2108,11 +4072,11 @@ func (b *BigIP) GetPolicy(name string) (*Policy, error) {
 		var a PolicyRuleActions
 		var c PolicyRuleConditions
 
-		err, _ = b.getForEntity(&a, uriLtm, uriPolicy, name, "rules", p.Rules[i].Name, "actions", policyVersionSuffix)
+		err, _ = b.getForEntity(&a, policyPath(query, uriLtm, uriPolicy, name, "rules", p.Rules[i].Name, "actions")...)
 		if err != nil {
 			return nil, err
 		}
-		err, _ = b.getForEntity(&c, uriLtm, uriPolicy, name, "rules", p.Rules[i].Name, "conditions", policyVersionSuffix)
+		err, _ = b.getForEntity(&c, policyPath(query, uriLtm, uriPolicy, name, "rules", p.Rules[i].Name, "conditions")...)
 		if err != nil {
 			return nil, err
 		}
@@ -2137,20 +4101,104 @@ func normalizePolicy(p *Policy) {
 }
 
 // Create a new policy. It is not necessary to set the Ordinal fields on subcollections.
+// On BIG-IP 12.1 and later the policy is created as a draft and published, unless Legacy
+// is set.
 func (b *BigIP) CreatePolicy(p *Policy) error {
 	normalizePolicy(p)
-	return b.post(p, uriLtm, uriPolicy, policyVersionSuffix)
+	drafts, err := b.policyDrafts()
+	if err != nil {
+		return err
+	}
+	if !drafts {
+		legacy := *p
+		legacy.Legacy = false
+		return b.post(&legacy, uriLtm, uriPolicy, policyVersionSuffix)
+	}
+	if p.Legacy {
+		return b.post(p, uriLtm, uriPolicy)
+	}
+
+	partition, name := splitFullPath(p.Name)
+	if p.Partition != "" {
+		partition = p.Partition
+	}
+	draft := *p
+	draft.Name = name
+	draft.Partition = partition
+	draft.SubPath = policyDraftsSubPath
+	draft.FullPath = ""
+	draft.Status = ""
+	if err := b.post(&draft, uriLtm, uriPolicy); err != nil {
+		return err
+	}
+
+	return b.PublishDraftPolicy(fmt.Sprintf("/%s/%s/%s", partition, policyDraftsSubPath, name))
 }
 
-// Update an existing policy.
+// Update an existing policy. On BIG-IP 12.1 and later the changes are made to a draft of
+// the policy, created if it does not exist yet, which is then published.
 func (b *BigIP) UpdatePolicy(name string, p *Policy) error {
 	normalizePolicy(p)
-	return b.put(p, uriLtm, uriPolicy, name, policyVersionSuffix)
+	drafts, err := b.policyDrafts()
+	if err != nil {
+		return err
+	}
+	if !drafts {
+		return b.put(p, uriLtm, uriPolicy, name, policyVersionSuffix)
+	}
+
+	partition, base := splitFullPath(name)
+	draftPath := fmt.Sprintf("/%s/%s/%s", partition, policyDraftsSubPath, base)
+
+	var draft Policy
+	err, ok := b.getForEntity(&draft, uriLtm, uriPolicy, draftPath)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		if err := b.CreateDraftFromPolicy(fmt.Sprintf("/%s/%s", partition, base)); err != nil {
+			return err
+		}
+	}
+
+	update := *p
+	update.Name = base
+	update.Partition = partition
+	update.SubPath = policyDraftsSubPath
+	update.FullPath = ""
+	update.Status = ""
+	update.Legacy = false
+	if err := b.put(&update, uriLtm, uriPolicy, draftPath); err != nil {
+		return err
+	}
+
+	return b.PublishDraftPolicy(draftPath)
 }
 
-// Delete a policy by name.
+// Delete a policy by name. On BIG-IP 12.1 and later its draft, if any, is deleted too.
 func (b *BigIP) DeletePolicy(name string) error {
-	return b.delete(uriLtm, uriPolicy, name, policyVersionSuffix)
+	drafts, err := b.policyDrafts()
+	if err != nil {
+		return err
+	}
+	if !drafts {
+		return b.delete(uriLtm, uriPolicy, name, policyVersionSuffix)
+	}
+
+	partition, base := splitFullPath(name)
+	draftPath := fmt.Sprintf("/%s/%s/%s", partition, policyDraftsSubPath, base)
+	var draft Policy
+	err, ok := b.getForEntity(&draft, uriLtm, uriPolicy, draftPath)
+	if err != nil {
+		return err
+	}
+	if ok {
+		if err := b.delete(uriLtm, uriPolicy, draftPath); err != nil {
+			return err
+		}
+	}
+
+	return b.delete(uriLtm, uriPolicy, name)
 }
 
 // CreateDraftFromPolicy called name. Name must be full name (ie ~partition~policyName).
@@ -2187,3 +4235,672 @@ func (b *BigIP) ModifyPolicyRule(policyName, ruleName string, rule PolicyRule) e
 func (b *BigIP) RemoveRuleFromPolicy(ruleName, policyName string) error {
 	return b.delete(uriLtm, uriPolicy, policyName, uriRules, ruleName)
 }
+
+// Condition is the starting point for building policy rule conditions, e.g.
+// Condition.HTTPHost().Equals("www.example.com"). The conditions match what GetPolicy
+// returns for the same rule.
+var Condition PolicyConditions
+
+// Action is the starting point for building policy rule actions, e.g.
+// Action.Forward().Pool("/Common/web_pool").
+var Action PolicyActions
+
+// PolicyConditions selects the part of the traffic a policy rule condition inspects.
+type PolicyConditions struct{}
+
+// PolicyConditionBuilder sets how a policy rule condition compares its operand.
+type PolicyConditionBuilder struct {
+	c PolicyRuleCondition
+}
+
+func newPolicyCondition(c PolicyRuleCondition) *PolicyConditionBuilder {
+	c.CaseInsensitive = true
+	c.External = true
+	c.Present = true
+	c.Remote = true
+	c.Request = true
+	return &PolicyConditionBuilder{c: c}
+}
+
+// HTTPHost matches the HTTP Host header.
+func (PolicyConditions) HTTPHost() *PolicyConditionBuilder {
+	return newPolicyCondition(PolicyRuleCondition{HttpHost: true, Host: true})
+}
+
+// HTTPURIPath matches the path of the HTTP request URI.
+func (PolicyConditions) HTTPURIPath() *PolicyConditionBuilder {
+	return newPolicyCondition(PolicyRuleCondition{HttpUri: true, Path: true})
+}
+
+// HTTPURIExtension matches the file extension of the HTTP request URI.
+func (PolicyConditions) HTTPURIExtension() *PolicyConditionBuilder {
+	return newPolicyCondition(PolicyRuleCondition{HttpUri: true, Extension: true})
+}
+
+// HTTPURIQueryString matches the query string of the HTTP request URI.
+func (PolicyConditions) HTTPURIQueryString() *PolicyConditionBuilder {
+	return newPolicyCondition(PolicyRuleCondition{HttpUri: true, QueryString: true})
+}
+
+// HTTPHeader matches the value of the named HTTP header.
+func (PolicyConditions) HTTPHeader(name string) *PolicyConditionBuilder {
+	return newPolicyCondition(PolicyRuleCondition{HttpHeader: true, TmName: name})
+}
+
+// HTTPCookie matches the value of the named HTTP cookie.
+func (PolicyConditions) HTTPCookie(name string) *PolicyConditionBuilder {
+	return newPolicyCondition(PolicyRuleCondition{HttpCookie: true, TmName: name})
+}
+
+// HTTPMethod matches the HTTP request method.
+func (PolicyConditions) HTTPMethod() *PolicyConditionBuilder {
+	return newPolicyCondition(PolicyRuleCondition{HttpMethod: true})
+}
+
+// HTTPUserAgent matches the full HTTP User-Agent header.
+func (PolicyConditions) HTTPUserAgent() *PolicyConditionBuilder {
+	return newPolicyCondition(PolicyRuleCondition{HttpUserAgent: true, All: true})
+}
+
+// HTTPStatus matches the status code of the HTTP response.
+func (PolicyConditions) HTTPStatus() *PolicyConditionBuilder {
+	b := newPolicyCondition(PolicyRuleCondition{HttpStatus: true, Code: true})
+	return b.OnResponse()
+}
+
+// TCPAddress matches the client address against addresses or networks in CIDR notation.
+func (PolicyConditions) TCPAddress() *PolicyConditionBuilder {
+	return newPolicyCondition(PolicyRuleCondition{Tcp: true, Address: true})
+}
+
+// SSLServerName matches the server name (SNI) sent in the TLS client hello.
+func (PolicyConditions) SSLServerName() *PolicyConditionBuilder {
+	b := newPolicyCondition(PolicyRuleCondition{SslExtension: true, ServerName: true})
+	b.c.Request = false
+	b.c.SslClientHello = true
+	return b
+}
+
+// Not negates the condition.
+func (b *PolicyConditionBuilder) Not() *PolicyConditionBuilder {
+	b.c.Not = true
+	return b
+}
+
+// CaseSensitive compares values case sensitively. Comparisons are case insensitive by default.
+func (b *PolicyConditionBuilder) CaseSensitive() *PolicyConditionBuilder {
+	b.c.CaseInsensitive = false
+	b.c.CaseSensitive = true
+	return b
+}
+
+// OnResponse evaluates the condition when the HTTP response is received instead of the request.
+func (b *PolicyConditionBuilder) OnResponse() *PolicyConditionBuilder {
+	b.c.Request = false
+	b.c.Response = true
+	return b
+}
+
+func (b *PolicyConditionBuilder) compare(set func(*PolicyRuleCondition), values []string) PolicyRuleCondition {
+	c := b.c
+	set(&c)
+	c.Values = values
+	return c
+}
+
+// Equals matches if the operand equals any of the values.
+func (b *PolicyConditionBuilder) Equals(values ...string) PolicyRuleCondition {
+	return b.compare(func(c *PolicyRuleCondition) { c.Equals = true }, values)
+}
+
+// StartsWith matches if the operand starts with any of the values.
+func (b *PolicyConditionBuilder) StartsWith(values ...string) PolicyRuleCondition {
+	return b.compare(func(c *PolicyRuleCondition) { c.StartsWith = true }, values)
+}
+
+// EndsWith matches if the operand ends with any of the values.
+func (b *PolicyConditionBuilder) EndsWith(values ...string) PolicyRuleCondition {
+	return b.compare(func(c *PolicyRuleCondition) { c.EndsWith = true }, values)
+}
+
+// Contains matches if the operand contains any of the values.
+func (b *PolicyConditionBuilder) Contains(values ...string) PolicyRuleCondition {
+	return b.compare(func(c *PolicyRuleCondition) { c.Contains = true }, values)
+}
+
+// Matches matches if the operand is in any of the values. Used for addresses, where the
+// values are addresses or networks in CIDR notation.
+func (b *PolicyConditionBuilder) Matches(values ...string) PolicyRuleCondition {
+	return b.compare(func(c *PolicyRuleCondition) { c.Matches = true }, values)
+}
+
+// Exists matches if the operand, e.g. a header or cookie, is present.
+func (b *PolicyConditionBuilder) Exists() PolicyRuleCondition {
+	return b.compare(func(c *PolicyRuleCondition) {}, nil)
+}
+
+// Missing matches if the operand, e.g. a header or cookie, is not present.
+func (b *PolicyConditionBuilder) Missing() PolicyRuleCondition {
+	return b.compare(func(c *PolicyRuleCondition) {
+		c.Present = false
+		c.Missing = true
+	}, nil)
+}
+
+// PolicyActions selects what a policy rule action does.
+type PolicyActions struct{}
+
+// PolicyForwardBuilder selects where a forward action sends the traffic.
+type PolicyForwardBuilder struct {
+	a PolicyRuleAction
+}
+
+// PolicyHTTPActionBuilder sets how an action modifies an HTTP header, host or URI.
+type PolicyHTTPActionBuilder struct {
+	a PolicyRuleAction
+}
+
+// Forward sends matching traffic somewhere.
+func (PolicyActions) Forward() *PolicyForwardBuilder {
+	return &PolicyForwardBuilder{a: PolicyRuleAction{Forward: true, Request: true}}
+}
+
+// Pool forwards the traffic to a pool.
+func (b *PolicyForwardBuilder) Pool(pool string) PolicyRuleAction {
+	a := b.a
+	a.Select = true
+	a.Pool = pool
+	return a
+}
+
+// Member forwards the traffic to a pool member, given as "<address>:<port>" of a member
+// of the given pool.
+func (b *PolicyForwardBuilder) Member(pool, member string) PolicyRuleAction {
+	a := b.a
+	a.Select = true
+	a.Pool = pool
+	a.Member = member
+	return a
+}
+
+// Node forwards the traffic to a node address.
+func (b *PolicyForwardBuilder) Node(node string) PolicyRuleAction {
+	a := b.a
+	a.Select = true
+	a.Node = node
+	return a
+}
+
+// Virtual forwards the traffic to another virtual server.
+func (b *PolicyForwardBuilder) Virtual(virtual string) PolicyRuleAction {
+	a := b.a
+	a.Select = true
+	a.Virtual = virtual
+	return a
+}
+
+// Reset resets the connection.
+func (b *PolicyForwardBuilder) Reset() PolicyRuleAction {
+	a := b.a
+	a.Reset = true
+	return a
+}
+
+// Redirect replies with an HTTP redirect to location. Prefix location with "tcl:" to use
+// Tcl expressions, e.g. "tcl:https://[HTTP::host][HTTP::uri]".
+func (PolicyActions) Redirect(location string) PolicyRuleAction {
+	return PolicyRuleAction{HttpReply: true, Redirect: true, Location: location, Request: true}
+}
+
+// Log writes a message to the local syslog.
+func (PolicyActions) Log(message string) PolicyRuleAction {
+	return PolicyRuleAction{Log: true, Write: true, Message: message, Request: true}
+}
+
+// HTTPHeader modifies an HTTP header.
+func (PolicyActions) HTTPHeader() *PolicyHTTPActionBuilder {
+	return &PolicyHTTPActionBuilder{a: PolicyRuleAction{HttpHeader: true, Request: true}}
+}
+
+// HTTPHost modifies the HTTP Host header.
+func (PolicyActions) HTTPHost() *PolicyHTTPActionBuilder {
+	return &PolicyHTTPActionBuilder{a: PolicyRuleAction{HttpHost: true, Request: true}}
+}
+
+// HTTPURI modifies the HTTP request URI.
+func (PolicyActions) HTTPURI() *PolicyHTTPActionBuilder {
+	return &PolicyHTTPActionBuilder{a: PolicyRuleAction{HttpUri: true, Request: true}}
+}
+
+// OnResponse modifies the HTTP response instead of the request.
+func (b *PolicyHTTPActionBuilder) OnResponse() *PolicyHTTPActionBuilder {
+	b.a.Request = false
+	b.a.Response = true
+	return b
+}
+
+// Insert adds the named header with value.
+func (b *PolicyHTTPActionBuilder) Insert(name, value string) PolicyRuleAction {
+	a := b.a
+	a.Insert = true
+	a.TmName = name
+	a.Value = value
+	return a
+}
+
+// Replace sets the named header to value. For the host and URI, name is ignored.
+func (b *PolicyHTTPActionBuilder) Replace(name, value string) PolicyRuleAction {
+	a := b.a
+	a.Replace = true
+	if a.HttpHeader {
+		a.TmName = name
+	}
+	a.Value = value
+	return a
+}
+
+// ReplacePath replaces the path of the HTTP request URI.
+func (b *PolicyHTTPActionBuilder) ReplacePath(path string) PolicyRuleAction {
+	a := b.a
+	a.Replace = true
+	a.Path = path
+	return a
+}
+
+// Remove removes the named header.
+func (b *PolicyHTTPActionBuilder) Remove(name string) PolicyRuleAction {
+	a := b.a
+	a.Remove = true
+	a.TmName = name
+	return a
+}
+
+// NewPolicyRule builds a policy rule from conditions and actions, e.g. built with
+// Condition and Action, and validates it.
+func NewPolicyRule(name string, conditions []PolicyRuleCondition, actions []PolicyRuleAction) (PolicyRule, error) {
+	rule := PolicyRule{
+		Name:       name,
+		Conditions: conditions,
+		Actions:    actions,
+	}
+	return rule, ValidatePolicyRule(rule)
+}
+
+// ValidatePolicyRule checks a policy rule for conditions and actions the BIG-IP would
+// reject or that cannot work together.
+func ValidatePolicyRule(rule PolicyRule) error {
+	response := false
+	for i, c := range rule.Conditions {
+		comparators := 0
+		for _, set := range []bool{c.Equals, c.StartsWith, c.EndsWith, c.Contains, c.Matches,
+			c.Less, c.LessOrEqual, c.Greater, c.GreaterOrEqual} {
+			if set {
+				comparators++
+			}
+		}
+		switch {
+		case comparators > 1:
+			return fmt.Errorf("rule %s: condition %d has more than one comparison", rule.Name, i)
+		case comparators == 1 && len(c.Values) == 0:
+			return fmt.Errorf("rule %s: condition %d has no values to compare", rule.Name, i)
+		case comparators == 0 && !c.Present && !c.Missing:
+			return fmt.Errorf("rule %s: condition %d has no comparison", rule.Name, i)
+		case comparators == 0 && len(c.Values) > 0:
+			return fmt.Errorf("rule %s: condition %d has values but no comparison", rule.Name, i)
+		}
+		if c.Tcp && c.Address && (c.StartsWith || c.EndsWith || c.Contains) {
+			return fmt.Errorf("rule %s: condition %d compares an address as a string, use Matches or Equals", rule.Name, i)
+		}
+		if c.Request && c.Response {
+			return fmt.Errorf("rule %s: condition %d is evaluated on both request and response", rule.Name, i)
+		}
+		if c.Response {
+			response = true
+		}
+	}
+
+	targets := 0
+	for i, a := range rule.Actions {
+		if a.Forward {
+			selected := 0
+			for _, set := range []bool{a.Pool != "" && a.Member == "", a.Member != "", a.Node != "", a.Virtual != "", a.Reset} {
+				if set {
+					selected++
+				}
+			}
+			if selected != 1 {
+				return fmt.Errorf("rule %s: forward action %d must select exactly one of pool, member, node, virtual or reset", rule.Name, i)
+			}
+		}
+		if a.Forward || a.Redirect {
+			targets++
+			if response {
+				return fmt.Errorf("rule %s: action %d forwards or redirects the request, but the rule matches on the response", rule.Name, i)
+			}
+		}
+		if (a.HttpHost || a.HttpUri) && (a.Insert || a.Remove) {
+			return fmt.Errorf("rule %s: action %d can only replace the host or URI", rule.Name, i)
+		}
+		if (a.HttpHeader || a.HttpHost || a.HttpUri) && !a.Insert && !a.Replace && !a.Remove {
+			return fmt.Errorf("rule %s: action %d does not insert, replace or remove anything", rule.Name, i)
+		}
+	}
+	if targets > 1 {
+		return fmt.Errorf("rule %s: only one forward or redirect action is allowed", rule.Name)
+	}
+
+	return nil
+}
+
+// DNSZones contains a list of every DNS Express zone on the BIG-IP system.
+type DNSZones struct {
+	DNSZones []DNSZone `json:"items"`
+}
+
+// DNSZone contains information about each ltm/dns/zone: a zone answered by DNS Express
+// from a copy transferred from DNSExpressServer.
+type DNSZone struct {
+	Name                       string   `json:"name,omitempty"`
+	Partition                  string   `json:"partition,omitempty"`
+	FullPath                   string   `json:"fullPath,omitempty"`
+	Generation                 int      `json:"generation,omitempty"`
+	DNSExpressAllowNotify      []string `json:"dnsExpressAllowNotify,omitempty"`
+	DNSExpressEnabled          string   `json:"dnsExpressEnabled,omitempty"`
+	DNSExpressNotifyAction     string   `json:"dnsExpressNotifyAction,omitempty"`
+	DNSExpressNotifyTsigVerify string   `json:"dnsExpressNotifyTsigVerify,omitempty"`
+	DNSExpressServer           string   `json:"dnsExpressServer,omitempty"`
+	ResponsePolicy             string   `json:"responsePolicy,omitempty"`
+	ServerTsigKey              string   `json:"serverTsigKey,omitempty"`
+	TransferClients            []string `json:"transferClients,omitempty"`
+}
+
+// DNSZoneStats contains the DNS Express status of a zone. Counters holds every counter
+// reported for the zone.
+type DNSZoneStats struct {
+	Serial      int64
+	Records     int64
+	Notifies    int64
+	AXFRQueries int64
+	IXFRQueries int64
+	Counters    map[string]StatValue
+}
+
+// DNSTsigKeys contains a list of every DNS TSIG key on the BIG-IP system.
+type DNSTsigKeys struct {
+	DNSTsigKeys []DNSTsigKey `json:"items"`
+}
+
+// DNSTsigKey contains information about each ltm/dns/tsig-key. Algorithm is one of
+// "hmac-md5", "hmac-sha1" or "hmac-sha256", and Secret is base64 encoded.
+type DNSTsigKey struct {
+	Name       string `json:"name,omitempty"`
+	Partition  string `json:"partition,omitempty"`
+	FullPath   string `json:"fullPath,omitempty"`
+	Generation int    `json:"generation,omitempty"`
+	Algorithm  string `json:"algorithm,omitempty"`
+	Secret     string `json:"secret,omitempty"`
+}
+
+// DNSNameservers contains a list of every DNS nameserver on the BIG-IP system.
+type DNSNameservers struct {
+	DNSNameservers []DNSNameserver `json:"items"`
+}
+
+// DNSNameserver contains information about each ltm/dns/nameserver: a DNS server that
+// zones are transferred from or notified to.
+type DNSNameserver struct {
+	Name        string `json:"name,omitempty"`
+	Partition   string `json:"partition,omitempty"`
+	FullPath    string `json:"fullPath,omitempty"`
+	Generation  int    `json:"generation,omitempty"`
+	Address     string `json:"address,omitempty"`
+	Port        int    `json:"port,omitempty"`
+	RouteDomain string `json:"routeDomain,omitempty"`
+	TsigKey     string `json:"tsigKey,omitempty"`
+}
+
+// DNSSECKeys contains a list of every DNSSEC key on the BIG-IP system.
+type DNSSECKeys struct {
+	DNSSECKeys []DNSSECKey `json:"items"`
+}
+
+// DNSSECKey contains information about each ltm/dns/dnssec/key. KeyType is "zsk" or
+// "ksk", and KeyManagement is "automatic" or "manual". The periods are tmsh durations in
+// seconds.
+type DNSSECKey struct {
+	Name                       string         `json:"name,omitempty"`
+	Partition                  string         `json:"partition,omitempty"`
+	FullPath                   string         `json:"fullPath,omitempty"`
+	Generation                 int            `json:"generation,omitempty"`
+	Algorithm                  string         `json:"algorithm,omitempty"`
+	BitWidth                   int            `json:"bitWidth,omitempty"`
+	CertKeyChain               []CertKeyChain `json:"certKeyChain,omitempty"`
+	Description                string         `json:"description,omitempty"`
+	ExpirationPeriod           int            `json:"expirationPeriod,omitempty"`
+	KeyManagement              string         `json:"keyManagement,omitempty"`
+	KeyType                    string         `json:"keyType,omitempty"`
+	RolloverPeriod             int            `json:"rolloverPeriod,omitempty"`
+	SignatureValidityPeriod    int            `json:"signatureValidityPeriod,omitempty"`
+	SignaturePublicationPeriod int            `json:"signaturePublicationPeriod,omitempty"`
+	TTL                        int            `json:"ttl,omitempty"`
+	UseFips                    string         `json:"useFips,omitempty"`
+}
+
+// DNSZones returns a list of DNS Express zones.
+func (b *BigIP) DNSZones() (*DNSZones, error) {
+	var zones DNSZones
+	err, _ := b.getForEntity(&zones, uriLtm, uriDns, uriDnsZone)
+	if err != nil {
+		return nil, err
+	}
+
+	return &zones, nil
+}
+
+// GetDNSZone gets a DNS Express zone by name. Returns nil if the zone does not exist.
+func (b *BigIP) GetDNSZone(name string) (*DNSZone, error) {
+	var zone DNSZone
+	err, ok := b.getForEntity(&zone, uriLtm, uriDns, uriDnsZone, name)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	return &zone, nil
+}
+
+// AddDNSZone adds a DNS Express zone. Name is the zone name, e.g. "example.com".
+func (b *BigIP) AddDNSZone(config *DNSZone) error {
+	return b.post(config, uriLtm, uriDns, uriDnsZone)
+}
+
+// PatchDNSZone allows you to change any attribute of a DNS Express zone. Sets only the
+// attributes specified.
+func (b *BigIP) PatchDNSZone(name string, config *DNSZone) error {
+	return b.patch(config, uriLtm, uriDns, uriDnsZone, name)
+}
+
+// DeleteDNSZone removes a DNS Express zone.
+func (b *BigIP) DeleteDNSZone(name string) error {
+	return b.delete(uriLtm, uriDns, uriDnsZone, name)
+}
+
+// TransferDNSZone forces a full transfer of a DNS Express zone from its DNS Express
+// server, by disabling and re-enabling DNS Express on the zone. Until it is re-enabled,
+// normally the time between the two requests, the BIG-IP does not answer for the zone
+// from DNS Express. If re-enabling fails it is retried once; if that fails too, both
+// errors are returned and DNS Express stays disabled. The transfer itself completes
+// asynchronously; see GetDNSZoneStats.
+func (b *BigIP) TransferDNSZone(name string) error {
+	zone, err := b.GetDNSZone(name)
+	if err != nil {
+		return err
+	}
+	if zone == nil {
+		return fmt.Errorf("DNS zone %s does not exist", name)
+	}
+	if zone.DNSExpressServer == "" {
+		return fmt.Errorf("DNS zone %s has no DNS Express server to transfer from", name)
+	}
+	if zone.DNSExpressEnabled != "yes" {
+		return fmt.Errorf("DNS Express is not enabled on DNS zone %s", name)
+	}
+
+	if err := b.PatchDNSZone(name, &DNSZone{DNSExpressEnabled: "no"}); err != nil {
+		return err
+	}
+	if err := b.PatchDNSZone(name, &DNSZone{DNSExpressEnabled: "yes"}); err != nil {
+		if retryErr := b.PatchDNSZone(name, &DNSZone{DNSExpressEnabled: "yes"}); retryErr != nil {
+			return withCleanupErrors(err, retryErr)
+		}
+	}
+
+	return nil
+}
+
+// GetDNSZoneStats returns the DNS Express status of a zone. Returns nil if the zone does
+// not exist.
+func (b *BigIP) GetDNSZoneStats(name string) (*DNSZoneStats, error) {
+	stats, err := b.getStats(uriLtm, uriDns, uriDnsZone, name)
+	if err != nil {
+		return nil, err
+	}
+	if stats == nil {
+		return nil, nil
+	}
+
+	return &DNSZoneStats{
+		Serial:      stats["dnsExpress.soaSerial"].Value,
+		Records:     stats["dnsExpress.records"].Value,
+		Notifies:    stats["dnsExpress.notifies"].Value,
+		AXFRQueries: stats["dnsExpress.axfrQueries"].Value,
+		IXFRQueries: stats["dnsExpress.ixfrQueries"].Value,
+		Counters:    stats,
+	}, nil
+}
+
+// DNSTsigKeys returns a list of DNS TSIG keys.
+func (b *BigIP) DNSTsigKeys() (*DNSTsigKeys, error) {
+	var keys DNSTsigKeys
+	err, _ := b.getForEntity(&keys, uriLtm, uriDns, uriTsigKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &keys, nil
+}
+
+// GetDNSTsigKey gets a DNS TSIG key by name. Returns nil if the key does not exist.
+func (b *BigIP) GetDNSTsigKey(name string) (*DNSTsigKey, error) {
+	var key DNSTsigKey
+	err, ok := b.getForEntity(&key, uriLtm, uriDns, uriTsigKey, name)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	return &key, nil
+}
+
+// AddDNSTsigKey adds a DNS TSIG key.
+func (b *BigIP) AddDNSTsigKey(config *DNSTsigKey) error {
+	return b.post(config, uriLtm, uriDns, uriTsigKey)
+}
+
+// PatchDNSTsigKey allows you to change any attribute of a DNS TSIG key. Sets only the
+// attributes specified.
+func (b *BigIP) PatchDNSTsigKey(name string, config *DNSTsigKey) error {
+	return b.patch(config, uriLtm, uriDns, uriTsigKey, name)
+}
+
+// DeleteDNSTsigKey removes a DNS TSIG key.
+func (b *BigIP) DeleteDNSTsigKey(name string) error {
+	return b.delete(uriLtm, uriDns, uriTsigKey, name)
+}
+
+// DNSNameservers returns a list of DNS nameservers.
+func (b *BigIP) DNSNameservers() (*DNSNameservers, error) {
+	var nameservers DNSNameservers
+	err, _ := b.getForEntity(&nameservers, uriLtm, uriDns, uriNameserver)
+	if err != nil {
+		return nil, err
+	}
+
+	return &nameservers, nil
+}
+
+// GetDNSNameserver gets a DNS nameserver by name. Returns nil if the nameserver does not exist.
+func (b *BigIP) GetDNSNameserver(name string) (*DNSNameserver, error) {
+	var nameserver DNSNameserver
+	err, ok := b.getForEntity(&nameserver, uriLtm, uriDns, uriNameserver, name)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	return &nameserver, nil
+}
+
+// AddDNSNameserver adds a DNS nameserver.
+func (b *BigIP) AddDNSNameserver(config *DNSNameserver) error {
+	return b.post(config, uriLtm, uriDns, uriNameserver)
+}
+
+// PatchDNSNameserver allows you to change any attribute of a DNS nameserver. Sets only the
+// attributes specified.
+func (b *BigIP) PatchDNSNameserver(name string, config *DNSNameserver) error {
+	return b.patch(config, uriLtm, uriDns, uriNameserver, name)
+}
+
+// DeleteDNSNameserver removes a DNS nameserver.
+func (b *BigIP) DeleteDNSNameserver(name string) error {
+	return b.delete(uriLtm, uriDns, uriNameserver, name)
+}
+
+// DNSSECKeys returns a list of DNSSEC keys.
+func (b *BigIP) DNSSECKeys() (*DNSSECKeys, error) {
+	var keys DNSSECKeys
+	err, _ := b.getForEntity(&keys, uriLtm, uriDns, uriDnssec, uriDnssecKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &keys, nil
+}
+
+// GetDNSSECKey gets a DNSSEC key by name. Returns nil if the key does not exist.
+func (b *BigIP) GetDNSSECKey(name string) (*DNSSECKey, error) {
+	var key DNSSECKey
+	err, ok := b.getForEntity(&key, uriLtm, uriDns, uriDnssec, uriDnssecKey, name)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	return &key, nil
+}
+
+// AddDNSSECKey adds a DNSSEC key. With automatic key management, the BIG-IP generates and
+// rolls over the key pairs itself.
+func (b *BigIP) AddDNSSECKey(config *DNSSECKey) error {
+	return b.post(config, uriLtm, uriDns, uriDnssec, uriDnssecKey)
+}
+
+// PatchDNSSECKey allows you to change any attribute of a DNSSEC key. Sets only the
+// attributes specified.
+func (b *BigIP) PatchDNSSECKey(name string, config *DNSSECKey) error {
+	return b.patch(config, uriLtm, uriDns, uriDnssec, uriDnssecKey, name)
+}
+
+// DeleteDNSSECKey removes a DNSSEC key.
+func (b *BigIP) DeleteDNSSECKey(name string) error {
+	return b.delete(uriLtm, uriDns, uriDnssec, uriDnssecKey, name)
+}
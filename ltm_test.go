@@ -1,10 +1,14 @@
 package bigip
 
 import (
+	"crypto/sha1"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"testing"
+	"time"
 
 	"io/ioutil"
 	"strings"
@@ -42,6 +46,7 @@ func (s *LTMTestSuite) TearDownSuite() {
 func (s *LTMTestSuite) SetupTest() {
 	s.ResponseFunc = nil
 	s.LastRequest = nil
+	s.Client.version = &versionCache{}
 }
 
 func TestLtmSuite(t *testing.T) {
@@ -108,7 +113,184 @@ func (s *LTMTestSuite) TestPatchVirtualAddress() {
 	assert.Equal(s.T(), "PATCH", s.LastRequest.Method)
 }
 
+const sysVersionResponse = `{
+  "kind": "tm:sys:version:versionstats",
+  "selfLink": "https://localhost/mgmt/tm/sys/version?ver=%[1]s",
+  "entries": {
+    "https://localhost/mgmt/tm/sys/version/0": {
+      "nestedStats": {
+        "entries": {
+          "Build": {"description": "0.0.6"},
+          "Edition": {"description": "Final"},
+          "Product": {"description": "BIG-IP"},
+          "Title": {"description": "Main Package"},
+          "Version": {"description": "%[1]s"}
+        }
+      }
+    }
+  }
+}`
+
+func (s *LTMTestSuite) TestPolicyDraftsVersionDetection() {
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(fmt.Sprintf(sysVersionResponse, "12.1.2")))
+	}
+	drafts, err := s.Client.policyDrafts()
+	assert.Nil(s.T(), err)
+	assert.True(s.T(), drafts)
+
+	s.Client.version = &versionCache{}
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(fmt.Sprintf(sysVersionResponse, "12.0.0")))
+	}
+	drafts, err = s.Client.policyDrafts()
+	assert.Nil(s.T(), err)
+	assert.False(s.T(), drafts)
+}
+
+func (s *LTMTestSuite) TestPolicyVersionError() {
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte("Unauthorized"))
+	}
+
+	_, err := s.Client.Policies()
+	assert.NotNil(s.T(), err)
+	err = s.Client.DeletePolicy("foo")
+	assert.NotNil(s.T(), err)
+	assert.Equal(s.T(), "/mgmt/tm/sys/version", s.LastRequest.URL.Path)
+}
+
+func (s *LTMTestSuite) TestCreatePolicyWithDrafts() {
+	var requests []string
+	var bodies []string
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.RequestURI())
+		bodies = append(bodies, s.LastRequestBody)
+		if r.URL.Path == "/mgmt/tm/sys/version" {
+			w.Write([]byte(fmt.Sprintf(sysVersionResponse, "13.1.0")))
+		}
+	}
+
+	err := s.Client.CreatePolicy(&Policy{
+		Name:     "test",
+		Strategy: "/Common/first-match",
+		Controls: []string{"forwarding"},
+		Requires: []string{"http"},
+		Rules:    []PolicyRule{{Name: "rule1"}},
+	})
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), []string{
+		"GET /mgmt/tm/sys/version",
+		"POST /mgmt/tm/ltm/policy",
+		"POST /mgmt/tm/ltm/policy",
+	}, requests)
+	assert.JSONEq(s.T(), `{"name":"test","partition":"Common","subPath":"Drafts","controls":["forwarding"],"requires":["http"],"strategy":"/Common/first-match",
+		"rulesReference":{"items":[{"name":"rule1","ordinal":0,"actionsReference":{},"conditionsReference":{}}]}}`, bodies[1])
+	assert.JSONEq(s.T(), `{"command":"publish","name":"/Common/Drafts/test"}`, bodies[2])
+}
+
+func (s *LTMTestSuite) TestCreateLegacyPolicyWithDrafts() {
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/mgmt/tm/sys/version" {
+			w.Write([]byte(fmt.Sprintf(sysVersionResponse, "13.1.0")))
+		}
+	}
+
+	err := s.Client.CreatePolicy(&Policy{Name: "test", Legacy: true})
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), "POST", s.LastRequest.Method)
+	assert.Equal(s.T(), "/mgmt/tm/ltm/policy", s.LastRequest.URL.RequestURI())
+	assert.JSONEq(s.T(), `{"name":"test","legacy":true,"rulesReference":{}}`, s.LastRequestBody)
+}
+
+func (s *LTMTestSuite) TestUpdatePolicyWithDrafts() {
+	var requests []string
+	var bodies []string
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.RequestURI())
+		bodies = append(bodies, s.LastRequestBody)
+		switch {
+		case r.URL.Path == "/mgmt/tm/sys/version":
+			w.Write([]byte(fmt.Sprintf(sysVersionResponse, "13.1.0")))
+		case r.Method == "GET":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code":404,"message":"01020036:3: The requested policy (/Common/Drafts/test) was not found.","errorStack":[]}`))
+		}
+	}
+
+	err := s.Client.UpdatePolicy("/Common/test", &Policy{Name: "test", Strategy: "/Common/best-match"})
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), []string{
+		"GET /mgmt/tm/sys/version",
+		"GET /mgmt/tm/ltm/policy/~Common~Drafts~test",
+		"PATCH /mgmt/tm/ltm/policy/~Common~test?options=create-draft",
+		"PUT /mgmt/tm/ltm/policy/~Common~Drafts~test",
+		"POST /mgmt/tm/ltm/policy",
+	}, requests)
+	assert.JSONEq(s.T(), `{"name":"test","partition":"Common","subPath":"Drafts","strategy":"/Common/best-match","rulesReference":{}}`, bodies[3])
+	assert.JSONEq(s.T(), `{"command":"publish","name":"/Common/Drafts/test"}`, bodies[4])
+}
+
+func (s *LTMTestSuite) TestUpdatePolicyFromGetPolicy() {
+	var bodies []string
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		bodies = append(bodies, r.Method+" "+r.URL.Path+" "+s.LastRequestBody)
+		switch {
+		case r.URL.Path == "/mgmt/tm/sys/version":
+			w.Write([]byte(fmt.Sprintf(sysVersionResponse, "13.1.0")))
+		case r.Method == "GET":
+			w.Write([]byte(`{"name":"test","partition":"Common","fullPath":"/Common/test","status":"published","strategy":"/Common/first-match","rulesReference":{"items":[]}}`))
+		}
+	}
+
+	p, err := s.Client.GetPolicy("/Common/test")
+	assert.Nil(s.T(), err)
+	p.Strategy = "/Common/best-match"
+	err = s.Client.UpdatePolicy("/Common/test", p)
+
+	assert.Nil(s.T(), err)
+	var put string
+	for _, b := range bodies {
+		if strings.HasPrefix(b, "PUT ") {
+			put = b
+		}
+	}
+	prefix := "PUT /mgmt/tm/ltm/policy/~Common~Drafts~test "
+	if assert.True(s.T(), strings.HasPrefix(put, prefix), put) {
+		assert.JSONEq(s.T(), `{"name":"test","partition":"Common","subPath":"Drafts","strategy":"/Common/best-match","rulesReference":{}}`, strings.TrimPrefix(put, prefix))
+	}
+	assert.Equal(s.T(), "/Common/test", p.FullPath)
+}
+
+func (s *LTMTestSuite) TestUpdatePolicyExistingDraft() {
+	var requests []string
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.RequestURI())
+		switch {
+		case r.URL.Path == "/mgmt/tm/sys/version":
+			w.Write([]byte(fmt.Sprintf(sysVersionResponse, "13.1.0")))
+		case r.Method == "GET":
+			w.Write([]byte(`{"name":"test","partition":"Common","subPath":"Drafts","fullPath":"/Common/Drafts/test","status":"draft"}`))
+		}
+	}
+
+	err := s.Client.UpdatePolicy("test", &Policy{Name: "test"})
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), []string{
+		"GET /mgmt/tm/sys/version",
+		"GET /mgmt/tm/ltm/policy/~Common~Drafts~test",
+		"PUT /mgmt/tm/ltm/policy/~Common~Drafts~test",
+		"POST /mgmt/tm/ltm/policy",
+	}, requests)
+}
+
 func (s *LTMTestSuite) TestGetPolicies() {
+	s.Client.version = &versionCache{version: "11.6.0"}
 	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte(`{
   "kind": "tm:ltm:policy:policycollectionstate",
@@ -170,6 +352,10 @@ func (s *LTMTestSuite) TestGetPolicies() {
 
 func (s *LTMTestSuite) TestGetPolicy() {
 	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/mgmt/tm/sys/version" {
+			w.Write([]byte(fmt.Sprintf(sysVersionResponse, "11.6.1")))
+			return
+		}
 		assert.Equal(s.T(), policyVersionSuffix, "?"+s.LastRequest.URL.RawQuery)
 		if strings.HasSuffix(r.URL.Path, "rules") {
 			w.Write([]byte(`{
@@ -317,6 +503,7 @@ func (s *LTMTestSuite) TestCreatePolicy() {
 		},
 	}
 
+	s.Client.version = &versionCache{version: "11.6.0"}
 	s.Client.CreatePolicy(&p)
 
 	assert.Equal(s.T(), "POST", s.LastRequest.Method)
@@ -355,6 +542,7 @@ func (s *LTMTestSuite) TestCreatePolicy() {
 
 func (s *LTMTestSuite) TestUpdatePolicy() {
 	//TODO: test more stuff
+	s.Client.version = &versionCache{version: "11.6.0"}
 	s.Client.UpdatePolicy("foo", &Policy{})
 
 	assert.Equal(s.T(), "PUT", s.LastRequest.Method)
@@ -363,6 +551,7 @@ func (s *LTMTestSuite) TestUpdatePolicy() {
 }
 
 func (s *LTMTestSuite) TestDeletePolicy() {
+	s.Client.version = &versionCache{version: "11.6.0"}
 	s.Client.DeletePolicy("foo")
 
 	assert.Equal(s.T(), "DELETE", s.LastRequest.Method)
@@ -370,6 +559,37 @@ func (s *LTMTestSuite) TestDeletePolicy() {
 	assert.Equal(s.T(), policyVersionSuffix, "?"+s.LastRequest.URL.RawQuery)
 }
 
+func (s *LTMTestSuite) TestCreateLegacyPolicyKeepsCaller() {
+	s.Client.version = &versionCache{version: "11.6.0"}
+	p := Policy{Name: "test", Legacy: true}
+	err := s.Client.CreatePolicy(&p)
+
+	assert.Nil(s.T(), err)
+	assert.True(s.T(), p.Legacy)
+	assert.Equal(s.T(), policyVersionSuffix, "?"+s.LastRequest.URL.RawQuery)
+	assert.NotContains(s.T(), s.LastRequestBody, "legacy")
+}
+
+func (s *LTMTestSuite) TestDeletePolicyWithDraft() {
+	s.Client.version = &versionCache{version: "13.1.0"}
+	var requests []string
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if r.Method == "GET" {
+			w.Write([]byte(`{"name":"foo","partition":"Common","subPath":"Drafts"}`))
+		}
+	}
+
+	err := s.Client.DeletePolicy("/Common/foo")
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), []string{
+		"GET /mgmt/tm/ltm/policy/~Common~Drafts~foo",
+		"DELETE /mgmt/tm/ltm/policy/~Common~Drafts~foo",
+		"DELETE /mgmt/tm/ltm/policy/~Common~foo",
+	}, requests)
+}
+
 func (s *LTMTestSuite) TestCreateVirtualAddress() {
 
 	s.Client.CreateVirtualAddress("test-va", &VirtualAddress{Address: "10.10.10.10", ARP: true, AutoDelete: false})
@@ -1118,6 +1338,1619 @@ func (s *LTMTestSuite) TestUpdatePoolMembers() {
 	assert.Equal(s.T(), `{"members":[{"name":"test-pool-member:80","partition":"Common","fullPath":"/Common/test-pool-member:80","monitor":"/Common/icmp"},{"name":"test-pool-member2:80","partition":"Common","fullPath":"/Common/test-pool-member2:80","monitor":"/Common/icmp"}]}`, s.LastRequestBody)
 }
 
+const poolMemberStatsResponse = `{
+  "kind": "tm:ltm:pool:members:membersstats",
+  "selfLink": "https://localhost/mgmt/tm/ltm/pool/~Common~test-pool/members/~Common~test-pool-member:80/stats?ver=12.1.2",
+  "entries": {
+    "https://localhost/mgmt/tm/ltm/pool/~Common~test-pool/members/~Common~test-pool-member:80/~Common~test-pool-member:80/stats": {
+      "nestedStats": {
+        "entries": {
+          "addr": {"description": "10.10.20.30"},
+          "port": {"value": 80},
+          "curSessions": {"value": 0},
+          "serverside.curConns": {"value": %d},
+          "serverside.maxConns": {"value": 12},
+          "serverside.totConns": {"value": 345},
+          "status.availabilityState": {"description": "available"},
+          "status.enabledState": {"description": "disabled"},
+          "status.statusReason": {"description": "Pool member is available, user disabled"}
+        }
+      }
+    }
+  }
+}`
+
+const poolMemberDrainResponse = `{
+  "name": "test-pool-member:80",
+  "partition": "Common",
+  "fullPath": "/Common/test-pool-member:80",
+  "address": "10.10.20.30",
+  "session": "user-disabled",
+  "state": "up"
+}`
+
+func (s *LTMTestSuite) TestGetPoolMemberStats() {
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(fmt.Sprintf(poolMemberStatsResponse, 3)))
+	}
+
+	stats, err := s.Client.GetPoolMemberStats("/Common/test-pool", "/Common/test-pool-member:80")
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), fmt.Sprintf("/mgmt/tm/%s/%s/%s/%s/%s/stats", uriLtm, uriPool, "~Common~test-pool", uriPoolMember, "~Common~test-pool-member:80"), s.LastRequest.URL.Path)
+	assert.Equal(s.T(), "10.10.20.30", stats.Address)
+	assert.Equal(s.T(), int64(80), stats.Port)
+	assert.Equal(s.T(), int64(3), stats.CurrentConnections)
+	assert.Equal(s.T(), int64(345), stats.TotalConnections)
+	assert.Equal(s.T(), "available", stats.AvailabilityState)
+	assert.Equal(s.T(), "disabled", stats.EnabledState)
+}
+
+func (s *LTMTestSuite) TestDrainPoolMember() {
+	var methods []string
+	var bodies []string
+	conns := 2
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/stats"):
+			w.Write([]byte(fmt.Sprintf(poolMemberStatsResponse, conns)))
+			conns--
+		case strings.HasSuffix(r.URL.Path, uriPersistRecords):
+			assert.Equal(s.T(), "node-addr 10.10.20.30 node-port 80 pool /Common/test-pool", r.URL.Query().Get("options"))
+			w.Write([]byte(`{"apiRawValues": {"apiAnonymous": "Sys::Persistent Connections\nTotal records returned: 0\n"}}`))
+		case r.Method == "GET":
+			w.Write([]byte(poolMemberDrainResponse))
+		default:
+			methods = append(methods, r.Method)
+			bodies = append(bodies, s.LastRequestBody)
+			w.Write([]byte(`{}`))
+		}
+	}
+
+	var progress []DrainStatus
+	err := s.Client.DrainPoolMember("/Common/test-pool", "/Common/test-pool-member:80", &DrainOptions{
+		Timeout:      time.Second,
+		PollInterval: time.Millisecond,
+		ForceOffline: true,
+		Progress: func(status *DrainStatus) {
+			progress = append(progress, *status)
+		},
+	})
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), []string{"PUT", "PUT"}, methods)
+	assert.JSONEq(s.T(), `{"session":"user-disabled","fqdn":{}}`, bodies[0])
+	assert.JSONEq(s.T(), `{"session":"user-disabled","state":"user-down","fqdn":{}}`, bodies[1])
+	assert.Equal(s.T(), 3, len(progress))
+	assert.Equal(s.T(), int64(2), progress[0].CurrentConnections)
+	assert.False(s.T(), progress[1].Drained)
+	assert.True(s.T(), progress[2].Drained)
+}
+
+func (s *LTMTestSuite) TestDrainPoolMemberTimeout() {
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/stats"):
+			w.Write([]byte(fmt.Sprintf(poolMemberStatsResponse, 5)))
+		case r.Method == "GET":
+			w.Write([]byte(poolMemberDrainResponse))
+		default:
+			w.Write([]byte(`{}`))
+		}
+	}
+
+	err := s.Client.DrainPoolMember("/Common/test-pool", "/Common/test-pool-member:80", &DrainOptions{
+		Timeout:           10 * time.Millisecond,
+		PollInterval:      time.Millisecond,
+		IgnorePersistence: true,
+	})
+
+	assert.EqualError(s.T(), err, "Timed out after 10ms waiting for /Common/test-pool-member:80 to drain")
+	assert.Equal(s.T(), "GET", s.LastRequest.Method)
+}
+
+func (s *LTMTestSuite) TestRestorePoolMember() {
+	s.Client.RestorePoolMember("/Common/test-pool", "/Common/test-pool-member:80")
+
+	assert.Equal(s.T(), "PUT", s.LastRequest.Method)
+	assert.Equal(s.T(), fmt.Sprintf("/mgmt/tm/%s/%s/%s/%s/%s", uriLtm, uriPool, "~Common~test-pool", uriPoolMember, "~Common~test-pool-member:80"), s.LastRequest.URL.Path)
+	assert.JSONEq(s.T(), `{"session":"user-enabled","state":"user-up","fqdn":{}}`, s.LastRequestBody)
+}
+
+const poolsWithMembersResponse = `{
+  "kind": "tm:ltm:pool:poolcollectionstate",
+  "items": [
+    {
+      "name": "web-pool",
+      "partition": "Common",
+      "fullPath": "/Common/web-pool",
+      "membersReference": {
+        "isSubcollection": true,
+        "items": [
+          {"name": "web-server1:80", "partition": "Common", "fullPath": "/Common/web-server1:80", "address": "10.10.20.30", "session": "monitor-enabled", "state": "up"},
+          {"name": "web-server2:80", "partition": "Common", "fullPath": "/Common/web-server2:80", "address": "10.10.20.31", "session": "monitor-enabled", "state": "up"}
+        ]
+      }
+    },
+    {
+      "name": "api-pool",
+      "partition": "tenant",
+      "fullPath": "/tenant/api-pool",
+      "membersReference": {
+        "isSubcollection": true,
+        "items": [
+          {"name": "/Common/web-server1:8080", "partition": "Common", "fullPath": "/Common/web-server1:8080", "address": "10.10.20.30", "session": "user-disabled", "state": "user-down"}
+        ]
+      }
+    },
+    {
+      "name": "empty-pool",
+      "partition": "Common",
+      "fullPath": "/Common/empty-pool",
+      "membersReference": {"isSubcollection": true}
+    }
+  ]
+}`
+
+func (s *LTMTestSuite) TestNodePoolMemberships() {
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(poolsWithMembersResponse))
+	}
+
+	for _, node := range []string{"web-server1", "/Common/web-server1", "10.10.20.30"} {
+		m, err := s.Client.NodePoolMemberships(node)
+
+		assert.Nil(s.T(), err)
+		assert.Equal(s.T(), fmt.Sprintf("/mgmt/tm/%s/%s", uriLtm, uriPool), s.LastRequest.URL.Path)
+		assert.Equal(s.T(), "true", s.LastRequest.URL.Query().Get("expandSubcollections"))
+		assert.Equal(s.T(), []NodePoolMembership{
+			{Pool: "/Common/web-pool", Member: "/Common/web-server1:80", Address: "10.10.20.30", Session: "monitor-enabled", State: "up"},
+			{Pool: "/tenant/api-pool", Member: "/Common/web-server1:8080", Address: "10.10.20.30", Session: "user-disabled", State: "user-down"},
+		}, m, node)
+	}
+}
+
+func (s *LTMTestSuite) TestNodePoolMembershipsPartition() {
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"items":[{"name":"web-pool","fullPath":"/tenant/web-pool","membersReference":{"items":[
+			{"name":"web-server1:80","fullPath":"/tenant/web-server1:80","address":"10.20.20.30"},
+			{"name":"/Common/web-server1:80","fullPath":"/Common/web-server1:80","address":"10.10.20.30"}]}}]}`))
+	}
+
+	m, err := s.Client.NodePoolMemberships("web-server1")
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), []NodePoolMembership{{Pool: "/tenant/web-pool", Member: "/Common/web-server1:80", Address: "10.10.20.30"}}, m)
+
+	m, err = s.Client.NodePoolMemberships("/tenant/web-server1")
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), []NodePoolMembership{{Pool: "/tenant/web-pool", Member: "/tenant/web-server1:80", Address: "10.20.20.30"}}, m)
+}
+
+func (s *LTMTestSuite) TestNodeMaintenance() {
+	var paths []string
+	var bodies []string
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			w.Write([]byte(poolsWithMembersResponse))
+			return
+		}
+		paths = append(paths, r.URL.Path)
+		bodies = append(bodies, s.LastRequestBody)
+		w.Write([]byte(`{}`))
+	}
+
+	snapshot, err := s.Client.NodeMaintenance("web-server1", "offline")
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), "web-server1", snapshot.Node)
+	assert.Equal(s.T(), 2, len(snapshot.Members))
+	assert.Equal(s.T(), []string{
+		fmt.Sprintf("/mgmt/tm/%s/%s/%s/%s/%s", uriLtm, uriPool, "~Common~web-pool", uriPoolMember, "~Common~web-server1:80"),
+		fmt.Sprintf("/mgmt/tm/%s/%s/%s/%s/%s", uriLtm, uriPool, "~tenant~api-pool", uriPoolMember, "~Common~web-server1:8080"),
+	}, paths)
+	for _, body := range bodies {
+		assert.JSONEq(s.T(), `{"session":"user-disabled","state":"user-down","fqdn":{}}`, body)
+	}
+
+	paths, bodies = nil, nil
+	err = s.Client.RestoreNodeMaintenance(snapshot)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), 2, len(paths))
+	assert.JSONEq(s.T(), `{"session":"user-enabled","state":"user-up","fqdn":{}}`, bodies[0])
+	assert.JSONEq(s.T(), `{"session":"user-disabled","state":"user-down","fqdn":{}}`, bodies[1])
+}
+
+func (s *LTMTestSuite) TestNodeMaintenanceInvalidState() {
+	_, err := s.Client.NodeMaintenance("web-server1", "enable")
+
+	assert.NotNil(s.T(), err)
+	assert.Nil(s.T(), s.LastRequest)
+}
+
+const poolStatsResponse = `{
+  "kind": "tm:ltm:pool:poolstats",
+  "entries": {
+    "https://localhost/mgmt/tm/ltm/pool/~Common~green/~Common~green/stats": {
+      "nestedStats": {
+        "entries": {
+          "activeMemberCnt": {"value": 1},
+          "memberCnt": {"value": 1},
+          "serverside.curConns": {"value": 7},
+          "serverside.totConns": {"value": 1024},
+          "status.availabilityState": {"description": "%s"},
+          "status.enabledState": {"description": "enabled"},
+          "status.statusReason": {"description": "The pool is available"}
+        }
+      }
+    }
+  }
+}`
+
+func (s *LTMTestSuite) TestGetPoolStats() {
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(fmt.Sprintf(poolStatsResponse, "available")))
+	}
+
+	stats, err := s.Client.GetPoolStats("/Common/green")
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), fmt.Sprintf("/mgmt/tm/%s/%s/%s/stats", uriLtm, uriPool, "~Common~green"), s.LastRequest.URL.Path)
+	assert.Equal(s.T(), int64(1), stats.ActiveMembers)
+	assert.Equal(s.T(), int64(7), stats.CurrentConnections)
+	assert.Equal(s.T(), "available", stats.AvailabilityState)
+	assert.Equal(s.T(), "The pool is available", stats.StatusReason)
+}
+
+func (s *LTMTestSuite) TestShiftTrafficAtomic() {
+	var calls []string
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path+" "+s.LastRequestBody)
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/stats"):
+			w.Write([]byte(fmt.Sprintf(poolStatsResponse, "available")))
+		case r.Method == "GET":
+			w.Write([]byte(`{"name": "vs", "fullPath": "/Common/vs", "pool": "/Common/blue"}`))
+		default:
+			w.Write([]byte(`{}`))
+		}
+	}
+
+	var progress []TrafficShiftStatus
+	err := s.Client.ShiftTraffic("/Common/vs", &TrafficShiftOptions{
+		ToPool:       "/Common/green",
+		StepInterval: time.Millisecond,
+		Progress: func(status *TrafficShiftStatus) {
+			progress = append(progress, *status)
+		},
+	})
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), []string{
+		"GET /mgmt/tm/ltm/virtual/~Common~vs ",
+		"GET /mgmt/tm/ltm/pool/~Common~green/stats ",
+		`PATCH /mgmt/tm/ltm/virtual/~Common~vs {"pool":"/Common/green"}`,
+		"GET /mgmt/tm/ltm/pool/~Common~green/stats ",
+	}, calls)
+	assert.Equal(s.T(), []TrafficShiftStatus{
+		{VirtualServer: "/Common/vs", FromPool: "/Common/blue", ToPool: "/Common/green", Step: 1, Percent: 100, Available: true},
+	}, progress)
+}
+
+func (s *LTMTestSuite) TestShiftTrafficRollback() {
+	var calls []string
+	checks := 0
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path+" "+s.LastRequestBody)
+		if strings.HasSuffix(r.URL.Path, "/stats") {
+			checks++
+			state := "available"
+			if checks > 1 {
+				state = "offline"
+			}
+			w.Write([]byte(fmt.Sprintf(poolStatsResponse, state)))
+			return
+		}
+		w.Write([]byte(`{}`))
+	}
+
+	err := s.Client.ShiftTraffic("/Common/vs", &TrafficShiftOptions{
+		FromPool:     "/Common/blue",
+		ToPool:       "/Common/green",
+		Method:       ShiftAtomic,
+		StepInterval: time.Millisecond,
+	})
+
+	assert.EqualError(s.T(), err, "pool /Common/green went down at step 1, rolled back /Common/vs to pool /Common/blue")
+	assert.Equal(s.T(), []string{
+		"GET /mgmt/tm/ltm/pool/~Common~green/stats ",
+		`PATCH /mgmt/tm/ltm/virtual/~Common~vs {"pool":"/Common/green"}`,
+		"GET /mgmt/tm/ltm/pool/~Common~green/stats ",
+		`PATCH /mgmt/tm/ltm/virtual/~Common~vs {"pool":"/Common/blue"}`,
+	}, calls)
+}
+
+func (s *LTMTestSuite) TestShiftTrafficRatio() {
+	var calls []string
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			calls = append(calls, r.Method+" "+r.URL.Path+" "+s.LastRequestBody)
+		}
+		switch r.URL.Path {
+		case "/mgmt/tm/ltm/pool/~Common~green/stats":
+			w.Write([]byte(fmt.Sprintf(poolStatsResponse, "available")))
+		case "/mgmt/tm/ltm/pool/~Common~blue":
+			w.Write([]byte(`{"name": "blue", "fullPath": "/Common/blue", "loadBalancingMode": "round-robin"}`))
+		case "/mgmt/tm/ltm/pool/~Common~blue/members":
+			w.Write([]byte(`{"items": [
+				{"name": "b1:80", "fullPath": "/Common/b1:80", "ratio": 1},
+				{"name": "b2:80", "fullPath": "/Common/b2:80", "ratio": 3, "priorityGroup": 2}
+			]}`))
+		case "/mgmt/tm/ltm/pool/~Common~green/members":
+			w.Write([]byte(`{"items": [{"name": "g1:80", "fullPath": "/Common/g1:80", "ratio": 1}]}`))
+		default:
+			w.Write([]byte(`{}`))
+		}
+	}
+
+	var percents []int
+	err := s.Client.ShiftTraffic("/Common/vs", &TrafficShiftOptions{
+		FromPool:     "/Common/blue",
+		ToPool:       "/Common/green",
+		Method:       ShiftRatio,
+		Steps:        []int{50},
+		StepInterval: time.Millisecond,
+		Progress: func(status *TrafficShiftStatus) {
+			percents = append(percents, status.Percent)
+		},
+	})
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), []int{50, 100}, percents)
+	assert.Equal(s.T(), []string{
+		`PATCH /mgmt/tm/ltm/pool/~Common~blue {"loadBalancingMode":"ratio-member"}`,
+		`POST /mgmt/tm/ltm/pool/~Common~blue/members {"name":"/Common/g1:80","ratio":1}`,
+		`PATCH /mgmt/tm/ltm/pool/~Common~blue/members/~Common~b1:80 {"priorityGroup":0,"ratio":1}`,
+		`PATCH /mgmt/tm/ltm/pool/~Common~blue/members/~Common~b2:80 {"priorityGroup":2,"ratio":1}`,
+		`PATCH /mgmt/tm/ltm/pool/~Common~blue/members/~Common~g1:80 {"priorityGroup":0,"ratio":2}`,
+		`PATCH /mgmt/tm/ltm/virtual/~Common~vs {"pool":"/Common/green"}`,
+		"DELETE /mgmt/tm/ltm/pool/~Common~blue/members/~Common~g1:80 ",
+		`PATCH /mgmt/tm/ltm/pool/~Common~blue/members/~Common~b1:80 {"priorityGroup":0,"ratio":1}`,
+		`PATCH /mgmt/tm/ltm/pool/~Common~blue/members/~Common~b2:80 {"priorityGroup":2,"ratio":3}`,
+		`PATCH /mgmt/tm/ltm/pool/~Common~blue {"loadBalancingMode":"round-robin"}`,
+	}, calls)
+}
+
+func (s *LTMTestSuite) TestShiftTrafficInvalidSteps() {
+	for _, steps := range [][]int{{100, 50}, {10, 10, 50}, {50, 20}, {0, 50}, {50, 150}} {
+		s.LastRequest = nil
+		err := s.Client.ShiftTraffic("/Common/vs", &TrafficShiftOptions{
+			FromPool: "/Common/blue",
+			ToPool:   "/Common/green",
+			Method:   ShiftRatio,
+			Steps:    steps,
+		})
+
+		assert.NotNil(s.T(), err, "%v", steps)
+		assert.Nil(s.T(), s.LastRequest, "%v", steps)
+	}
+}
+
+func (s *LTMTestSuite) TestPersistenceRecords() {
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{
+  "kind": "tm:ltm:persistence:persist-records:persist-recordsstats",
+  "apiRawValues": {
+    "apiAnonymous": "Sys::Persistent Connections\nsource-address  10.1.1.1  10.1.1.100:80  10.1.20.11:80  (tmm: 1)\nsource-address  10.1.1.2  10.1.1.100:80  10.1.20.12:80  (tmm: 0)\nTotal records returned: 2\n"
+  }
+}`))
+	}
+
+	records, err := s.Client.PersistenceRecords(&PersistenceFilter{Virtual: "/Common/test-vs"})
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), "GET", s.LastRequest.Method)
+	assert.Equal(s.T(), fmt.Sprintf("/mgmt/tm/%s/%s/%s", uriLtm, uriPersistence, uriPersistRecords), s.LastRequest.URL.Path)
+	assert.Equal(s.T(), "virtual /Common/test-vs", s.LastRequest.URL.Query().Get("options"))
+	assert.Equal(s.T(), []PersistenceRecord{
+		{Mode: "source-address", Value: "10.1.1.1", Virtual: "10.1.1.100:80", Node: "10.1.20.11:80", TMM: 1},
+		{Mode: "source-address", Value: "10.1.1.2", Virtual: "10.1.1.100:80", Node: "10.1.20.12:80", TMM: 0},
+	}, records)
+}
+
+func (s *LTMTestSuite) TestDeletePersistenceRecords() {
+	err := s.Client.DeletePersistenceRecords(&PersistenceFilter{Mode: "source-address", ClientAddress: "10.1.1.1"})
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), "DELETE", s.LastRequest.Method)
+	assert.Equal(s.T(), fmt.Sprintf("/mgmt/tm/%s/%s/%s", uriLtm, uriPersistence, uriPersistRecords), s.LastRequest.URL.Path)
+	assert.Equal(s.T(), "client-addr 10.1.1.1 mode source-address", s.LastRequest.URL.Query().Get("options"))
+}
+
+func (s *LTMTestSuite) TestDeletePersistenceRecordsRequiresFilter() {
+	err := s.Client.DeletePersistenceRecords(nil)
+
+	assert.NotNil(s.T(), err)
+	assert.Nil(s.T(), s.LastRequest)
+}
+
+func (s *LTMTestSuite) TestCreateSnat() {
+	err := s.Client.CreateSnat("test-snat", "/Common/10.1.1.50", []string{"10.1.20.0/24"})
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), "POST", s.LastRequest.Method)
+	assert.Equal(s.T(), fmt.Sprintf("/mgmt/tm/%s/%s", uriLtm, uriSnat), s.LastRequest.URL.Path)
+	assert.JSONEq(s.T(), `{"name":"test-snat","translation":"/Common/10.1.1.50","origins":[{"name":"10.1.20.0/24"}]}`, s.LastRequestBody)
+}
+
+func (s *LTMTestSuite) TestCreateSnatAutomap() {
+	err := s.Client.CreateSnat("test-snat", "", []string{"10.1.20.0/24"})
+
+	assert.Nil(s.T(), err)
+	assert.JSONEq(s.T(), `{"name":"test-snat","automap":true,"origins":[{"name":"10.1.20.0/24"}]}`, s.LastRequestBody)
+}
+
+func (s *LTMTestSuite) TestGetSnat() {
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{
+  "kind": "tm:ltm:snat:snatstate",
+  "name": "test-snat",
+  "partition": "Common",
+  "fullPath": "/Common/test-snat",
+  "generation": 1,
+  "autoLasthop": "default",
+  "mirror": "disabled",
+  "snatpool": "/Common/test-snatpool",
+  "sourcePort": "preserve",
+  "vlansEnabled": true,
+  "vlans": ["/Common/internal"],
+  "origins": [{"name": "10.1.20.0/24"}]
+}`))
+	}
+
+	snat, err := s.Client.GetSnat("test-snat")
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), fmt.Sprintf("/mgmt/tm/%s/%s/%s", uriLtm, uriSnat, "test-snat"), s.LastRequest.URL.Path)
+	assert.Equal(s.T(), "/Common/test-snatpool", snat.Snatpool)
+	assert.Equal(s.T(), []SnatOrigin{{Name: "10.1.20.0/24"}}, snat.Origins)
+	assert.Equal(s.T(), []string{"/Common/internal"}, snat.Vlans)
+	assert.True(s.T(), snat.VlansEnabled)
+}
+
+func (s *LTMTestSuite) TestGetSnatNotFound() {
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"code":404,"message":"01020036:3: The requested SNAT (/Common/test-snat) was not found.","errorStack":[]}`))
+	}
+
+	snat, err := s.Client.GetSnat("test-snat")
+
+	assert.Nil(s.T(), err)
+	assert.Nil(s.T(), snat)
+}
+
+func (s *LTMTestSuite) TestCreateSnatTranslation() {
+	err := s.Client.CreateSnatTranslation("10.1.1.50", "10.1.1.50", "/Common/traffic-group-1")
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), "POST", s.LastRequest.Method)
+	assert.Equal(s.T(), fmt.Sprintf("/mgmt/tm/%s/%s", uriLtm, uriSnatTranslation), s.LastRequest.URL.Path)
+	assert.JSONEq(s.T(), `{"name":"10.1.1.50","address":"10.1.1.50","trafficGroup":"/Common/traffic-group-1"}`, s.LastRequestBody)
+}
+
+func (s *LTMTestSuite) TestSnatTranslationStatus() {
+	err := s.Client.SnatTranslationStatus("10.1.1.50", DISABLED)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), "PATCH", s.LastRequest.Method)
+	assert.Equal(s.T(), fmt.Sprintf("/mgmt/tm/%s/%s/%s", uriLtm, uriSnatTranslation, "10.1.1.50"), s.LastRequest.URL.Path)
+	assert.JSONEq(s.T(), `{"disabled":true}`, s.LastRequestBody)
+
+	err = s.Client.SnatTranslationStatus("10.1.1.50", ENABLED)
+
+	assert.Nil(s.T(), err)
+	assert.JSONEq(s.T(), `{"enabled":true}`, s.LastRequestBody)
+}
+
+func (s *LTMTestSuite) TestCreateNat() {
+	err := s.Client.CreateNat("test-nat", "10.1.20.11", "10.1.1.60")
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), "POST", s.LastRequest.Method)
+	assert.Equal(s.T(), fmt.Sprintf("/mgmt/tm/%s/%s", uriLtm, uriNat), s.LastRequest.URL.Path)
+	assert.JSONEq(s.T(), `{"name":"test-nat","originatingAddress":"10.1.20.11","translationAddress":"10.1.1.60"}`, s.LastRequestBody)
+}
+
+func (s *LTMTestSuite) TestNatStatus() {
+	err := s.Client.NatStatus("test-nat", DISABLED)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), "PATCH", s.LastRequest.Method)
+	assert.Equal(s.T(), fmt.Sprintf("/mgmt/tm/%s/%s/%s", uriLtm, uriNat, "test-nat"), s.LastRequest.URL.Path)
+	assert.JSONEq(s.T(), `{"disabled":true}`, s.LastRequestBody)
+}
+
+func (s *LTMTestSuite) TestNatStatusInvalidState() {
+	err := s.Client.NatStatus("test-nat", "offline")
+
+	assert.NotNil(s.T(), err)
+	assert.Nil(s.T(), s.LastRequest)
+}
+
+func (s *LTMTestSuite) TestVirtualServerSourceAddressTranslation() {
+	err := s.Client.VirtualServerSourceAddressTranslation("test-vs", "snat", "/Common/test-snatpool")
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), "PATCH", s.LastRequest.Method)
+	assert.Equal(s.T(), fmt.Sprintf("/mgmt/tm/%s/%s/%s", uriLtm, uriVirtual, "test-vs"), s.LastRequest.URL.Path)
+	assert.JSONEq(s.T(), `{"sourceAddressTranslation":{"type":"snat","pool":"/Common/test-snatpool"}}`, s.LastRequestBody)
+}
+
+func (s *LTMTestSuite) TestCreateExternalDataGroup() {
+	var requests []string
+	var bodies []string
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		bodies = append(bodies, s.LastRequestBody)
+		if strings.Contains(r.URL.Path, uriUploads) {
+			w.Write([]byte(`{"remainingByteCount":0,"usedChunks":{"0":44},"totalByteCount":44,"localFilePath":"/var/config/rest/downloads/blocklist","temporaryFilePath":"/var/config/rest/downloads/tmp/blocklist","generation":0,"lastUpdateMicros":1492110084000000}`))
+		}
+	}
+
+	err := s.Client.CreateExternalDataGroup("blocklist", "ip", []DataGroupRecord{
+		{Name: "10.1.1.1", Data: "scanner"},
+		{Name: "10.2.0.0/16"},
+	})
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), []string{
+		"POST /mgmt/shared/file-transfer/uploads/blocklist",
+		fmt.Sprintf("POST /mgmt/tm/%s/%s/%s", uriSys, uriFile, uriDatagroup),
+		fmt.Sprintf("POST /mgmt/tm/%s/%s/%s", uriLtm, uriDatagroup, uriExternal),
+		fmt.Sprintf("POST /mgmt/tm/%s/%s", uriUtil, uriUnixRm),
+	}, requests)
+	assert.Equal(s.T(), "host 10.1.1.1 := \"scanner\",\nnetwork 10.2.0.0/16,\n", bodies[0])
+	assert.JSONEq(s.T(), `{"name":"blocklist","sourcePath":"file:/var/config/rest/downloads/blocklist","type":"ip"}`, bodies[1])
+	assert.JSONEq(s.T(), `{"name":"blocklist","externalFileName":"blocklist"}`, bodies[2])
+	assert.JSONEq(s.T(), `{"command":"run","utilCmdArgs":"/var/config/rest/downloads/blocklist"}`, bodies[3])
+}
+
+func (s *LTMTestSuite) TestCreateExternalDataGroupCleanup() {
+	var requests []string
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch {
+		case strings.Contains(r.URL.Path, uriUploads):
+			w.Write([]byte(`{"remainingByteCount":0,"totalByteCount":10,"localFilePath":"/var/config/rest/downloads/blocklist"}`))
+		case strings.Contains(r.URL.Path, uriExternal):
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"code":409,"message":"The requested data group (/Common/blocklist) already exists","errorStack":[]}`))
+		}
+	}
+
+	err := s.Client.CreateExternalDataGroup("blocklist", "ip", []DataGroupRecord{{Name: "10.1.1.1"}})
+
+	assert.EqualError(s.T(), err, "The requested data group (/Common/blocklist) already exists")
+	assert.Equal(s.T(), []string{
+		"POST /mgmt/shared/file-transfer/uploads/blocklist",
+		fmt.Sprintf("POST /mgmt/tm/%s/%s/%s", uriSys, uriFile, uriDatagroup),
+		fmt.Sprintf("POST /mgmt/tm/%s/%s/%s", uriLtm, uriDatagroup, uriExternal),
+		fmt.Sprintf("DELETE /mgmt/tm/%s/%s/%s/blocklist", uriSys, uriFile, uriDatagroup),
+		fmt.Sprintf("POST /mgmt/tm/%s/%s", uriUtil, uriUnixRm),
+	}, requests)
+}
+
+func (s *LTMTestSuite) TestReplaceExternalDataGroupRecords() {
+	var requests []string
+	var bodies []string
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		bodies = append(bodies, s.LastRequestBody)
+		switch {
+		case strings.Contains(r.URL.Path, uriUploads):
+			w.Write([]byte(`{"remainingByteCount":0,"totalByteCount":24,"localFilePath":"/var/config/rest/downloads/allowlist"}`))
+		case strings.Contains(r.URL.Path, uriExternal):
+			w.Write([]byte(`{"name":"allowlist","partition":"Common","fullPath":"/Common/allowlist","externalFileName":"/Common/allowlist-file","type":"string"}`))
+		case r.Method == "GET":
+			w.Write([]byte(`{"name":"allowlist-file","partition":"Common","fullPath":"/Common/allowlist-file","sourcePath":"file:/var/config/rest/downloads/allowlist","type":"string"}`))
+		}
+	}
+
+	err := s.Client.ReplaceExternalDataGroupRecords("/Common/allowlist", []DataGroupRecord{
+		{Name: "example.com", Data: "yes"},
+	})
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), []string{
+		fmt.Sprintf("GET /mgmt/tm/%s/%s/%s/~Common~allowlist", uriLtm, uriDatagroup, uriExternal),
+		fmt.Sprintf("GET /mgmt/tm/%s/%s/%s/~Common~allowlist-file", uriSys, uriFile, uriDatagroup),
+		"POST /mgmt/shared/file-transfer/uploads/allowlist-file",
+		fmt.Sprintf("PUT /mgmt/tm/%s/%s/%s/~Common~allowlist-file", uriSys, uriFile, uriDatagroup),
+		fmt.Sprintf("POST /mgmt/tm/%s/%s", uriUtil, uriUnixRm),
+	}, requests)
+	assert.JSONEq(s.T(), `{"sourcePath":"file:/var/config/rest/downloads/allowlist"}`, bodies[3])
+	assert.JSONEq(s.T(), `{"command":"run","utilCmdArgs":"/var/config/rest/downloads/allowlist"}`, bodies[4])
+}
+
+func (s *LTMTestSuite) TestFormatDataGroupRecords() {
+	data, err := formatDataGroupRecords("string", []DataGroupRecord{{Name: "a\"b", Data: "1"}, {Name: "c"}})
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), "\"a\\\"b\" := \"1\",\n\"c\",\n", string(data))
+
+	data, err = formatDataGroupRecords("integer", []DataGroupRecord{{Name: "42", Data: "answer"}})
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), "42 := \"answer\",\n", string(data))
+
+	_, err = formatDataGroupRecords("address", []DataGroupRecord{{Name: "10.1.1.1"}})
+	assert.NotNil(s.T(), err)
+}
+
+const internalDataGroupResponse = `{
+  "kind": "tm:ltm:data-group:internal:internalstate",
+  "name": "allowlist",
+  "partition": "Common",
+  "fullPath": "/Common/allowlist",
+  "generation": 7,
+  "type": "string",
+  "records": [
+    {"name": "a.example.com", "data": "1"},
+    {"name": "b.example.com", "data": "2"}
+  ]
+}`
+
+func (s *LTMTestSuite) TestAddDataGroupRecords() {
+	var requests []string
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Query().Get("options"))
+		if r.Method == "GET" {
+			w.Write([]byte(internalDataGroupResponse))
+		}
+	}
+
+	err := s.Client.AddDataGroupRecords("allowlist", []DataGroupRecord{
+		{Name: "a.example.com", Data: "changed"},
+		{Name: "c.example.com", Data: "3"},
+	})
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), []string{
+		"GET ",
+		`PATCH records add { "c.example.com" { data "3" } }`,
+	}, requests)
+	assert.Equal(s.T(), fmt.Sprintf("/mgmt/tm/%s/%s/%s/%s", uriLtm, uriDatagroup, uriInternal, "allowlist"), s.LastRequest.URL.Path)
+	assert.JSONEq(s.T(), `{}`, s.LastRequestBody)
+}
+
+func (s *LTMTestSuite) TestAddDataGroupRecordsNoChange() {
+	var requests []string
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method)
+		w.Write([]byte(internalDataGroupResponse))
+	}
+
+	err := s.Client.AddDataGroupRecords("allowlist", []DataGroupRecord{{Name: "b.example.com"}})
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), []string{"GET"}, requests)
+}
+
+func (s *LTMTestSuite) TestUpsertDataGroupRecords() {
+	var requests []string
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Query().Get("options"))
+		if r.Method == "GET" {
+			w.Write([]byte(internalDataGroupResponse))
+		}
+	}
+
+	err := s.Client.UpsertDataGroupRecords("allowlist", []DataGroupRecord{
+		{Name: "a.example.com", Data: "1"},
+		{Name: "b.example.com", Data: "20"},
+		{Name: "c.example.com", Data: "3"},
+	})
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), []string{
+		"GET ",
+		`PATCH records add { "c.example.com" { data "3" } }`,
+		`PATCH records modify { "b.example.com" { data "20" } }`,
+	}, requests)
+}
+
+func (s *LTMTestSuite) TestRemoveDataGroupRecords() {
+	var requests []string
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Query().Get("options"))
+		if r.Method == "GET" {
+			w.Write([]byte(internalDataGroupResponse))
+		}
+	}
+
+	err := s.Client.RemoveDataGroupRecords("allowlist", []string{"a.example.com", "missing.example.com"})
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), []string{
+		"GET ",
+		`PATCH records delete { "a.example.com" }`,
+	}, requests)
+}
+
+func (s *LTMTestSuite) TestUpsertDataGroupRecordsFallback() {
+	var requests []string
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method)
+		switch r.Method {
+		case "GET":
+			w.Write([]byte(internalDataGroupResponse))
+		case "PATCH":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code":400,"message":"Found invalid option records","errorStack":[]}`))
+		}
+	}
+
+	err := s.Client.UpsertDataGroupRecords("allowlist", []DataGroupRecord{
+		{Name: "c.example.com", Data: "3"},
+		{Name: "a.example.com", Data: "10"},
+	})
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), []string{"GET", "PATCH", "GET", "PUT"}, requests)
+	assert.JSONEq(s.T(), `{"generation":7,"records":[{"name":"a.example.com","data":"10"},{"name":"b.example.com","data":"2"},{"name":"c.example.com","data":"3"}]}`, s.LastRequestBody)
+}
+
+func (s *LTMTestSuite) TestUpsertDataGroupRecordsFallbackGenerationChanged() {
+	// Another client adds d.example.com after the records were first read.
+	changed := strings.Replace(internalDataGroupResponse, `"generation": 7`, `"generation": 8`, 1)
+	changed = strings.Replace(changed, `{"name": "b.example.com", "data": "2"}`, `{"name": "b.example.com", "data": "2"}, {"name": "d.example.com", "data": "4"}`, 1)
+	gets := 0
+	var requests []string
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method)
+		switch r.Method {
+		case "GET":
+			gets++
+			if gets == 1 {
+				w.Write([]byte(internalDataGroupResponse))
+			} else {
+				w.Write([]byte(changed))
+			}
+		case "PATCH":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code":400,"message":"Found invalid option records","errorStack":[]}`))
+		}
+	}
+
+	err := s.Client.UpsertDataGroupRecords("allowlist", []DataGroupRecord{{Name: "c.example.com", Data: "3"}})
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), []string{"GET", "PATCH", "GET", "GET", "PUT"}, requests)
+	assert.JSONEq(s.T(), `{"generation":8,"records":[{"name":"a.example.com","data":"1"},{"name":"b.example.com","data":"2"},{"name":"d.example.com","data":"4"},{"name":"c.example.com","data":"3"}]}`, s.LastRequestBody)
+}
+
+func (s *LTMTestSuite) TestUpsertDataGroupRecordsFallbackKeepsChanging() {
+	generation := 7
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			generation++
+			w.Write([]byte(strings.Replace(internalDataGroupResponse, `"generation": 7`, fmt.Sprintf(`"generation": %d`, generation), 1)))
+		case "PATCH":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code":400,"message":"Found invalid option records","errorStack":[]}`))
+		case "PUT":
+			s.T().Error("records were replaced although the data group kept changing")
+		}
+	}
+
+	err := s.Client.UpsertDataGroupRecords("allowlist", []DataGroupRecord{{Name: "c.example.com", Data: "3"}})
+
+	assert.EqualError(s.T(), err, "internal data group allowlist kept changing, gave up after 3 attempts")
+}
+
+func (s *LTMTestSuite) TestUpsertDataGroupRecordsNoFallbackOnOtherErrors() {
+	var requests []string
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Query().Get("options"))
+		switch {
+		case r.Method == "GET":
+			w.Write([]byte(internalDataGroupResponse))
+		case strings.Contains(r.URL.Query().Get("options"), "modify"):
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"code":500,"message":"mcpd is not responding","errorStack":[]}`))
+		}
+	}
+
+	err := s.Client.UpsertDataGroupRecords("allowlist", []DataGroupRecord{
+		{Name: "b.example.com", Data: "20"},
+		{Name: "c.example.com", Data: "3"},
+	})
+
+	assert.EqualError(s.T(), err, "mcpd is not responding")
+	assert.Equal(s.T(), []string{
+		"GET ",
+		`PATCH records add { "c.example.com" { data "3" } }`,
+		`PATCH records modify { "b.example.com" { data "20" } }`,
+	}, requests)
+}
+
+func (s *LTMTestSuite) TestMonitorTypes() {
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{
+  "kind": "tm:ltm:monitor:monitorcollectionstate",
+  "selfLink": "https://localhost/mgmt/tm/ltm/monitor?ver=13.1.0",
+  "items": [
+    {"reference": {"link": "https://localhost/mgmt/tm/ltm/monitor/dns?ver=13.1.0"}},
+    {"reference": {"link": "https://localhost/mgmt/tm/ltm/monitor/http?ver=13.1.0"}},
+    {"reference": {"link": "https://localhost/mgmt/tm/ltm/monitor/tcp-half-open?ver=13.1.0"}}
+  ]
+}`))
+	}
+
+	types, err := s.Client.MonitorTypes()
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), fmt.Sprintf("/mgmt/tm/%s/%s", uriLtm, uriMonitor), s.LastRequest.URL.Path)
+	assert.Equal(s.T(), []string{"dns", "http", "tcp-half-open"}, types)
+}
+
+func (s *LTMTestSuite) TestMonitors() {
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/mgmt/tm/ltm/monitor":
+			w.Write([]byte(`{"items": [
+  {"reference": {"link": "https://localhost/mgmt/tm/ltm/monitor/dns?ver=13.1.0"}},
+  {"reference": {"link": "https://localhost/mgmt/tm/ltm/monitor/snmp-dca?ver=13.1.0"}},
+  {"reference": {"link": "https://localhost/mgmt/tm/ltm/monitor/radius?ver=13.1.0"}}
+]}`))
+		case "/mgmt/tm/ltm/monitor/dns":
+			w.Write([]byte(`{"items": [{
+  "name": "dns-check",
+  "partition": "Common",
+  "fullPath": "/Common/dns-check",
+  "defaultsFrom": "/Common/dns",
+  "qname": "www.example.com",
+  "qtype": "a",
+  "acceptRcode": "no-error",
+  "answerContains": "query-type",
+  "adaptive": "disabled",
+  "interval": 5,
+  "timeout": 16
+}]}`))
+		case "/mgmt/tm/ltm/monitor/snmp-dca":
+			w.Write([]byte(`{"items": [{
+  "name": "snmp-check",
+  "fullPath": "/Common/snmp-check",
+  "defaultsFrom": "/Common/snmp_dca",
+  "agentType": "UCD",
+  "community": "public",
+  "cpuCoefficient": "1.5",
+  "cpuThreshold": "80",
+  "version": "v1"
+}]}`))
+		case "/mgmt/tm/ltm/monitor/radius":
+			w.Write([]byte(`{"items": []}`))
+		}
+	}
+
+	monitors, err := s.Client.Monitors()
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), 2, len(monitors))
+	assert.Equal(s.T(), "dns", monitors[0].MonitorType)
+	assert.Equal(s.T(), "www.example.com", monitors[0].DNS.QueryName)
+	assert.Equal(s.T(), "a", monitors[0].DNS.QueryType)
+	assert.Equal(s.T(), "no-error", monitors[0].DNS.AcceptRcode)
+	assert.Nil(s.T(), monitors[0].SNMPDCA)
+	assert.Equal(s.T(), "snmp-dca", monitors[1].MonitorType)
+	assert.Equal(s.T(), "public", monitors[1].SNMPDCA.Community)
+	assert.Equal(s.T(), "80", monitors[1].SNMPDCA.CPUThreshold)
+}
+
+func (s *LTMTestSuite) TestMonitorsError() {
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/mgmt/tm/ltm/monitor":
+			w.Write([]byte(`{"items": [
+  {"reference": {"link": "https://localhost/mgmt/tm/ltm/monitor/http?ver=13.1.0"}},
+  {"reference": {"link": "https://localhost/mgmt/tm/ltm/monitor/sip?ver=13.1.0"}}
+]}`))
+		case "/mgmt/tm/ltm/monitor/sip", "/mgmt/tm/ltm/monitor/http":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"code":500,"message":"internal error","errorStack":[]}`))
+		default:
+			w.Write([]byte(`{"items": []}`))
+		}
+	}
+
+	monitors, err := s.Client.Monitors()
+
+	assert.EqualError(s.T(), err, "failed to get monitors: http: internal error; sip: internal error")
+	assert.Nil(s.T(), monitors)
+}
+
+func (s *LTMTestSuite) TestMonitorsBoundsConcurrency() {
+	var items []string
+	for i := 0; i < 3*maxMonitorTypeRequests; i++ {
+		items = append(items, fmt.Sprintf(`{"reference": {"link": "https://localhost/mgmt/tm/ltm/monitor/type%d?ver=13.1.0"}}`, i))
+	}
+	var mu sync.Mutex
+	var running, maxRunning int
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/mgmt/tm/ltm/monitor" {
+			w.Write([]byte(`{"items": [` + strings.Join(items, ",") + `]}`))
+			return
+		}
+		mu.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		mu.Unlock()
+		time.Sleep(5 * time.Millisecond)
+		mu.Lock()
+		running--
+		mu.Unlock()
+		w.Write([]byte(`{"items": [{"name": "m"}]}`))
+	}
+
+	monitors, err := s.Client.Monitors()
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), len(items), len(monitors))
+	assert.True(s.T(), maxRunning <= maxMonitorTypeRequests, "%d concurrent requests", maxRunning)
+}
+
+func (s *LTMTestSuite) TestAddMonitorSIP() {
+	config := &Monitor{
+		Name:          "sip-check",
+		ParentMonitor: "/Common/sip",
+		Mode:          "tcp",
+		SIP:           &MonitorSIP{Request: "OPTIONS sip:example.com SIP/2.0", Headers: "Via: SIP/2.0/TCP 10.1.1.1"},
+		TLS:           &MonitorTLS{Compatibility: "enabled"},
+	}
+
+	err := s.Client.AddMonitor(config, "sip")
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), fmt.Sprintf("/mgmt/tm/%s/%s/%s", uriLtm, uriMonitor, "sip"), s.LastRequest.URL.Path)
+	assert.JSONEq(s.T(), `{"name":"sip-check","defaultsFrom":"/Common/sip","mode":"tcp","request":"OPTIONS sip:example.com SIP/2.0","headers":"Via: SIP/2.0/TCP 10.1.1.1","compatibility":"enabled","manualResume":"disabled","reverse":"disabled","transparent":"disabled","responseTime":0,"retryTime":0}`, s.LastRequestBody)
+}
+
+func (s *LTMTestSuite) TestDeployExternalMonitor() {
+	script := []byte("#!/bin/sh\necho up\n")
+	sum := sha1.Sum(script)
+	current := fmt.Sprintf("go-bigip-check-app-%x", sum[:4])
+
+	var requests []string
+	var bodies []string
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		bodies = append(bodies, s.LastRequestBody)
+		notFound := func() {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code":404,"message":"not found","errorStack":[]}`))
+		}
+		switch {
+		case strings.Contains(r.URL.Path, uriUploads):
+			w.Write([]byte(fmt.Sprintf(`{"localFilePath":"/var/config/rest/downloads/%s"}`, current)))
+		case r.Method == "GET" && r.URL.Path == "/mgmt/tm/sys/file/external-monitor":
+			w.Write([]byte(fmt.Sprintf(`{"items":[
+  {"name":"%s","fullPath":"/Common/%s"},
+  {"name":"go-bigip-check-app-0badf00d","fullPath":"/Common/go-bigip-check-app-0badf00d"},
+  {"name":"go-bigip-check-app-deadbeef","fullPath":"/Common/go-bigip-check-app-deadbeef"},
+  {"name":"check-app-0badf00d","fullPath":"/Common/check-app-0badf00d"},
+  {"name":"check-app-custom","fullPath":"/Common/check-app-custom"}
+]}`, current, current)))
+		case r.Method == "GET" && r.URL.Path == "/mgmt/tm/ltm/monitor/external":
+			w.Write([]byte(fmt.Sprintf(`{"items":[
+  {"name":"check-app","fullPath":"/Common/check-app","run":"/Common/%s"},
+  {"name":"pinned","fullPath":"/Common/pinned","run":"/Common/go-bigip-check-app-deadbeef"}
+]}`, current)))
+		case r.Method == "GET":
+			notFound()
+		}
+	}
+
+	err := s.Client.DeployExternalMonitor(&ExternalMonitorConfig{
+		Name:          "check-app",
+		ParentMonitor: "/Common/external",
+		Script:        script,
+		Interval:      10,
+		Timeout:       31,
+		Variables:     map[string]string{"PATH_INFO": "/health", "HOST": "app.example.com"},
+	})
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), []string{
+		fmt.Sprintf("GET /mgmt/tm/sys/file/external-monitor/%s", current),
+		fmt.Sprintf("POST /mgmt/shared/file-transfer/uploads/%s", current),
+		"POST /mgmt/tm/sys/file/external-monitor",
+		"POST /mgmt/tm/util/unix-rm",
+		"GET /mgmt/tm/ltm/monitor/external/check-app",
+		"POST /mgmt/tm/ltm/monitor/external",
+		"PATCH /mgmt/tm/ltm/monitor/external/check-app",
+		"GET /mgmt/tm/sys/file/external-monitor",
+		"GET /mgmt/tm/ltm/monitor/external",
+		"DELETE /mgmt/tm/sys/file/external-monitor/~Common~go-bigip-check-app-0badf00d",
+	}, requests)
+	assert.Equal(s.T(), string(script), bodies[1])
+	assert.JSONEq(s.T(), fmt.Sprintf(`{"name":"%s","sourcePath":"file:/var/config/rest/downloads/%s"}`, current, current), bodies[2])
+	assert.JSONEq(s.T(), fmt.Sprintf(`{"command":"run","utilCmdArgs":"/var/config/rest/downloads/%s"}`, current), bodies[3])
+	assert.JSONEq(s.T(), fmt.Sprintf(`{"name":"check-app","defaultsFrom":"/Common/external","run":"%s","interval":10,"timeout":31,"manualResume":"disabled","reverse":"disabled","transparent":"disabled","responseTime":0,"retryTime":0}`, current), bodies[5])
+}
+
+func (s *LTMTestSuite) TestDeployExternalMonitorImportFails() {
+	var requests []string
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch {
+		case strings.Contains(r.URL.Path, uriUploads):
+			w.Write([]byte(`{"localFilePath":"/var/config/rest/downloads/check-app"}`))
+		case r.Method == "GET":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code":404,"message":"not found","errorStack":[]}`))
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code":400,"message":"request failed","errorStack":[]}`))
+		}
+	}
+
+	err := s.Client.DeployExternalMonitor(&ExternalMonitorConfig{Name: "check-app", Script: []byte("#!/bin/sh\n")})
+
+	assert.EqualError(s.T(), err, "request failed (cleanup failed: request failed)")
+	assert.Equal(s.T(), "POST /mgmt/tm/util/unix-rm", requests[len(requests)-1])
+	assert.Equal(s.T(), 4, len(requests))
+}
+
+func (s *LTMTestSuite) TestDeployExternalMonitorUpdateVariables() {
+	script := []byte("#!/bin/sh\necho up\n")
+	sum := sha1.Sum(script)
+	current := fmt.Sprintf("go-bigip-check-app-%x", sum[:4])
+
+	var options []string
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "PATCH":
+			options = append(options, r.URL.Query().Get("options"))
+		case r.URL.Path == "/mgmt/tm/sys/file/external-monitor/"+current:
+			w.Write([]byte(fmt.Sprintf(`{"name":"%s","fullPath":"/Common/%s"}`, current, current)))
+		case r.URL.Path == "/mgmt/tm/ltm/monitor/external/check-app":
+			w.Write([]byte(`{"name":"check-app","fullPath":"/Common/check-app","run":"/Common/go-bigip-check-app-0badf00d","apiRawValues":{"userDefined HOST":"old.example.com","userDefined STALE":"1","userDefined PATH_INFO":"/health"}}`))
+		default:
+			w.Write([]byte(`{"items":[]}`))
+		}
+	}
+
+	err := s.Client.DeployExternalMonitor(&ExternalMonitorConfig{
+		Name:      "check-app",
+		Script:    script,
+		Variables: map[string]string{"PATH_INFO": "/health", "HOST": "app.example.com"},
+	})
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), []string{
+		"",
+		`user-defined STALE none user-defined HOST "app.example.com"`,
+	}, options)
+}
+
+func (s *LTMTestSuite) TestIsScriptVersion() {
+	assert.True(s.T(), isScriptVersion("go-bigip-check-app-0badf00d", "check-app"))
+	assert.False(s.T(), isScriptVersion("check-app-0badf00d", "check-app"))
+	assert.False(s.T(), isScriptVersion("go-bigip-check-app-custom", "check-app"))
+	assert.False(s.T(), isScriptVersion("go-bigip-check-app-v2-0badf00d", "check-app"))
+	assert.False(s.T(), isScriptVersion("go-bigip-check-0badf00d", "check-app"))
+}
+
+func (s *LTMTestSuite) TestValidateIRule() {
+	var requests []string
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+	}
+
+	diagnostics, err := s.Client.ValidateIRule("when HTTP_REQUEST { HTTP::respond 200 }")
+
+	assert.Nil(s.T(), err)
+	assert.Nil(s.T(), diagnostics)
+	assert.Equal(s.T(), 2, len(requests))
+	assert.Equal(s.T(), fmt.Sprintf("POST /mgmt/tm/%s/%s", uriLtm, uriIRule), requests[0])
+	assert.True(s.T(), strings.HasPrefix(requests[1], "DELETE /mgmt/tm/ltm/rule/~Common~__validate_irule_"), requests[1])
+}
+
+func (s *LTMTestSuite) TestValidateIRuleInvalid() {
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"code":400,"message":"01070151:3: Rule [/Common/__validate_irule_1] error: /Common/__validate_irule_1:2: error: [undefined procedure: HTTP::respnd][HTTP::respnd 200]\n/Common/__validate_irule_1:3: error: [parse error: missing close-brace][{]","errorStack":[],"apiError":3}`))
+	}
+
+	diagnostics, err := s.Client.ValidateIRule("when HTTP_REQUEST {\n  HTTP::respnd 200\n")
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), "POST", s.LastRequest.Method)
+	assert.Equal(s.T(), []IRuleDiagnostic{
+		{Line: 2, Severity: "error", Message: "undefined procedure: HTTP::respnd"},
+		{Line: 3, Severity: "error", Message: "parse error: missing close-brace"},
+	}, diagnostics)
+}
+
+func (s *LTMTestSuite) TestValidateIRuleRequestError() {
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"code":401,"message":"Authorization failed","errorStack":[]}`))
+	}
+
+	diagnostics, err := s.Client.ValidateIRule("when HTTP_REQUEST {}")
+
+	assert.NotNil(s.T(), err)
+	assert.Nil(s.T(), diagnostics)
+}
+
+func (s *LTMTestSuite) TestReplaceIRuleSafely() {
+	var requests []string
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/mgmt/tm/ltm/rule/~Common~redirect":
+			w.Write([]byte(`{"name":"redirect","partition":"Common","fullPath":"/Common/redirect","apiAnonymous":"when HTTP_REQUEST {}"}`))
+		case r.Method == "GET" && r.URL.Path == "/mgmt/tm/ltm/virtual":
+			w.Write([]byte(`{"items":[
+  {"name":"vs1","fullPath":"/Common/vs1","rules":["/Common/other","/Common/redirect"]},
+  {"name":"vs2","fullPath":"/Common/vs2","rules":["/Common/other"]},
+  {"name":"vs3","fullPath":"/Common/vs3"}
+]}`))
+		}
+	}
+
+	virtuals, err := s.Client.ReplaceIRuleSafely("/Common/redirect", "when HTTP_REQUEST { HTTP::redirect https://[HTTP::host][HTTP::uri] }")
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), []string{"/Common/vs1"}, virtuals)
+	assert.Equal(s.T(), 5, len(requests))
+	assert.Equal(s.T(), "PUT /mgmt/tm/ltm/rule/~Common~redirect", requests[4])
+	assert.JSONEq(s.T(), `{"name":"/Common/redirect","apiAnonymous":"when HTTP_REQUEST { HTTP::redirect https://[HTTP::host][HTTP::uri] }"}`, s.LastRequestBody)
+}
+
+func (s *LTMTestSuite) TestReplaceIRuleSafelyInvalid() {
+	var methods []string
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		switch {
+		case r.Method == "POST":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code":400,"message":"01070151:3: Rule [/Common/__validate_irule_1] error: /Common/__validate_irule_1:1: error: [undefined procedure: HTTP::respnd][HTTP::respnd 200]","errorStack":[]}`))
+		case r.URL.Path == "/mgmt/tm/ltm/virtual":
+			w.Write([]byte(`{"items":[{"name":"vs1","fullPath":"/Common/vs1","rules":["/Common/redirect"]}]}`))
+		default:
+			w.Write([]byte(`{"name":"redirect","partition":"Common","fullPath":"/Common/redirect"}`))
+		}
+	}
+
+	virtuals, err := s.Client.ReplaceIRuleSafely("redirect", "when HTTP_REQUEST { HTTP::respnd 200 }")
+
+	assert.Equal(s.T(), []string{"/Common/vs1"}, virtuals)
+	syntaxErr, ok := err.(*IRuleSyntaxError)
+	s.Require().True(ok, "expected an *IRuleSyntaxError, got %v", err)
+	assert.Equal(s.T(), []IRuleDiagnostic{{Line: 1, Severity: "error", Message: "undefined procedure: HTTP::respnd"}}, syntaxErr.Diagnostics)
+	assert.Equal(s.T(), []string{"GET", "GET", "POST"}, methods)
+}
+
+func (s *LTMTestSuite) TestPolicyConditionBuilder() {
+	c := Condition.HTTPHost().Equals("www.example.com")
+
+	b, err := json.Marshal(c)
+	assert.Nil(s.T(), err)
+	assert.JSONEq(s.T(), `{"caseInsensitive":true,"equals":true,"external":true,"host":true,"httpHost":true,"present":true,"remote":true,"request":true,"values":["www.example.com"]}`, string(b))
+
+	c = Condition.HTTPHeader("X-Env").Not().CaseSensitive().StartsWith("prod")
+	assert.True(s.T(), c.HttpHeader)
+	assert.Equal(s.T(), "X-Env", c.TmName)
+	assert.True(s.T(), c.Not)
+	assert.True(s.T(), c.CaseSensitive)
+	assert.False(s.T(), c.CaseInsensitive)
+	assert.True(s.T(), c.StartsWith)
+
+	c = Condition.HTTPCookie("session").Missing()
+	assert.True(s.T(), c.Missing)
+	assert.False(s.T(), c.Present)
+	assert.Nil(s.T(), c.Values)
+}
+
+func (s *LTMTestSuite) TestPolicyBuilderRoundTrip() {
+	// As returned by GetPolicy for "if host equals www.example.com forward to pool web_pool".
+	var conditions PolicyRuleConditions
+	err := json.Unmarshal([]byte(`{"items":[{
+  "kind": "tm:ltm:policy:rules:conditions:conditionsstate",
+  "name": "0",
+  "fullPath": "0",
+  "generation": 1,
+  "caseInsensitive": true,
+  "equals": true,
+  "external": true,
+  "host": true,
+  "httpHost": true,
+  "index": 0,
+  "present": true,
+  "remote": true,
+  "request": true,
+  "values": ["www.example.com"]
+}]}`), &conditions)
+	s.Require().Nil(err)
+	var actions PolicyRuleActions
+	err = json.Unmarshal([]byte(`{"items":[{
+  "kind": "tm:ltm:policy:rules:actions:actionsstate",
+  "name": "0",
+  "fullPath": "0",
+  "generation": 1,
+  "code": 0,
+  "expirySecs": 0,
+  "forward": true,
+  "length": 0,
+  "offset": 0,
+  "pool": "/Common/web_pool",
+  "port": 0,
+  "request": true,
+  "select": true,
+  "status": 0,
+  "vlanId": 0
+}]}`), &actions)
+	s.Require().Nil(err)
+
+	rule, err := NewPolicyRule("rule1",
+		[]PolicyRuleCondition{Condition.HTTPHost().Equals("www.example.com")},
+		[]PolicyRuleAction{Action.Forward().Pool("/Common/web_pool")},
+	)
+	s.Require().Nil(err)
+
+	fetched := conditions.Items[0]
+	fetched.Name = ""
+	fetched.Generation = 0
+	assert.Equal(s.T(), fetched, rule.Conditions[0])
+	fetchedAction := actions.Items[0]
+	fetchedAction.Name = ""
+	assert.Equal(s.T(), fetchedAction, rule.Actions[0])
+	assert.Nil(s.T(), ValidatePolicyRule(PolicyRule{Name: "fetched", Conditions: conditions.Items, Actions: actions.Items}))
+}
+
+func (s *LTMTestSuite) TestPolicyActionBuilder() {
+	a := Action.Redirect("tcl:https://[HTTP::host][HTTP::uri]")
+	b, err := json.Marshal(a)
+	assert.Nil(s.T(), err)
+	assert.JSONEq(s.T(), `{"httpReply":true,"redirect":true,"location":"tcl:https://[HTTP::host][HTTP::uri]","request":true}`, string(b))
+
+	a = Action.HTTPHeader().OnResponse().Insert("Strict-Transport-Security", "max-age=31536000")
+	b, err = json.Marshal(a)
+	assert.Nil(s.T(), err)
+	assert.JSONEq(s.T(), `{"httpHeader":true,"insert":true,"tmName":"Strict-Transport-Security","value":"max-age=31536000","response":true}`, string(b))
+
+	a = Action.HTTPHost().Replace("", "internal.example.com")
+	assert.Equal(s.T(), "", a.TmName)
+	assert.Equal(s.T(), "internal.example.com", a.Value)
+
+	a = Action.Forward().Member("/Common/web_pool", "10.1.20.11:80")
+	assert.Equal(s.T(), "/Common/web_pool", a.Pool)
+	assert.Equal(s.T(), "10.1.20.11:80", a.Member)
+}
+
+func (s *LTMTestSuite) TestValidatePolicyRule() {
+	_, err := NewPolicyRule("two-targets",
+		[]PolicyRuleCondition{Condition.HTTPURIPath().StartsWith("/api")},
+		[]PolicyRuleAction{Action.Forward().Pool("/Common/api_pool"), Action.Redirect("https://example.com")},
+	)
+	assert.NotNil(s.T(), err)
+
+	_, err = NewPolicyRule("forward-on-response",
+		[]PolicyRuleCondition{Condition.HTTPStatus().Equals("404")},
+		[]PolicyRuleAction{Action.Forward().Pool("/Common/api_pool")},
+	)
+	assert.NotNil(s.T(), err)
+
+	_, err = NewPolicyRule("address-contains",
+		[]PolicyRuleCondition{Condition.TCPAddress().Contains("10.1")},
+		[]PolicyRuleAction{Action.Forward().Reset()},
+	)
+	assert.NotNil(s.T(), err)
+
+	_, err = NewPolicyRule("no-values",
+		[]PolicyRuleCondition{Condition.HTTPHost().Equals()},
+		[]PolicyRuleAction{Action.Forward().Reset()},
+	)
+	assert.NotNil(s.T(), err)
+
+	_, err = NewPolicyRule("forward-nowhere", nil, []PolicyRuleAction{{Forward: true, Request: true}})
+	assert.NotNil(s.T(), err)
+
+	_, err = NewPolicyRule("insert-host", nil, []PolicyRuleAction{Action.HTTPHost().Insert("Host", "x")})
+	assert.NotNil(s.T(), err)
+
+	_, err = NewPolicyRule("block-internal",
+		[]PolicyRuleCondition{Condition.TCPAddress().Not().Matches("10.0.0.0/8"), Condition.HTTPURIPath().StartsWith("/admin")},
+		[]PolicyRuleAction{Action.Log("blocked admin access"), Action.Forward().Reset()},
+	)
+	assert.Nil(s.T(), err)
+}
+
+func (s *LTMTestSuite) TestAddClientSSLCertKeyChain() {
+	var requests []string
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch {
+		case strings.Contains(r.URL.Path, uriSslCert):
+			w.Write([]byte(`{"name":"app-ecdsa.crt","fullPath":"/Common/app-ecdsa.crt"}`))
+		case strings.Contains(r.URL.Path, uriSslKey):
+			w.Write([]byte(`{"name":"app-ecdsa.key","fullPath":"/Common/app-ecdsa.key"}`))
+		case r.Method == "GET":
+			w.Write([]byte(`{"name":"app-ssl","fullPath":"/Common/app-ssl","certKeyChain":[{"name":"app-rsa","cert":"/Common/app-rsa.crt","key":"/Common/app-rsa.key"}]}`))
+		}
+	}
+
+	err := s.Client.AddClientSSLCertKeyChain("app-ssl", CertKeyChain{Cert: "/Common/app-ecdsa.crt", Key: "/Common/app-ecdsa.key"})
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), []string{
+		"GET /mgmt/tm/sys/file/ssl-cert/~Common~app-ecdsa.crt",
+		"GET /mgmt/tm/sys/file/ssl-key/~Common~app-ecdsa.key",
+		"GET /mgmt/tm/ltm/profile/client-ssl/app-ssl",
+		"PATCH /mgmt/tm/ltm/profile/client-ssl/app-ssl",
+	}, requests)
+	assert.JSONEq(s.T(), `{"certKeyChain":[
+		{"name":"app-rsa","cert":"/Common/app-rsa.crt","key":"/Common/app-rsa.key"},
+		{"name":"app-ecdsa","cert":"/Common/app-ecdsa.crt","key":"/Common/app-ecdsa.key"}
+	]}`, s.LastRequestBody)
+}
+
+func (s *LTMTestSuite) TestAddClientSSLCertKeyChainMissingKey() {
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, uriSslKey) {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code":404,"message":"01020036:3: The requested key was not found.","errorStack":[]}`))
+			return
+		}
+		w.Write([]byte(`{"name":"app-ecdsa.crt","fullPath":"/Common/app-ecdsa.crt"}`))
+	}
+
+	err := s.Client.AddClientSSLCertKeyChain("app-ssl", CertKeyChain{Cert: "/Common/app-ecdsa.crt", Key: "/Common/app-ecdsa.key"})
+
+	assert.NotNil(s.T(), err)
+	assert.Equal(s.T(), "GET", s.LastRequest.Method)
+}
+
+func (s *LTMTestSuite) TestRemoveClientSSLCertKeyChain() {
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			w.Write([]byte(`{"name":"app-ssl","fullPath":"/Common/app-ssl","certKeyChain":[
+				{"name":"app-rsa","cert":"/Common/app-rsa.crt","key":"/Common/app-rsa.key"},
+				{"name":"app-ecdsa","cert":"/Common/app-ecdsa.crt","key":"/Common/app-ecdsa.key"}]}`))
+		}
+	}
+
+	err := s.Client.RemoveClientSSLCertKeyChain("app-ssl", "app-rsa")
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), "PATCH", s.LastRequest.Method)
+	assert.JSONEq(s.T(), `{"certKeyChain":[{"name":"app-ecdsa","cert":"/Common/app-ecdsa.crt","key":"/Common/app-ecdsa.key"}]}`, s.LastRequestBody)
+}
+
+func (s *LTMTestSuite) TestRemoveLastClientSSLCertKeyChain() {
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"name":"app-ssl","fullPath":"/Common/app-ssl","certKeyChain":[{"name":"app-rsa","cert":"/Common/app-rsa.crt","key":"/Common/app-rsa.key"}]}`))
+	}
+
+	err := s.Client.RemoveClientSSLCertKeyChain("app-ssl", "app-rsa")
+
+	assert.NotNil(s.T(), err)
+	assert.Equal(s.T(), "GET", s.LastRequest.Method)
+}
+
+func (s *LTMTestSuite) TestSetVirtualServerSNIDefault() {
+	var requests []string
+	var bodies []string
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		bodies = append(bodies, s.LastRequestBody)
+		switch r.URL.Path {
+		case "/mgmt/tm/ltm/virtual/app-vs/profiles":
+			w.Write([]byte(`{"items":[
+				{"name":"http","fullPath":"/Common/http","context":"all"},
+				{"name":"a-ssl","fullPath":"/Common/a-ssl","context":"clientside"},
+				{"name":"b-ssl","fullPath":"/Common/b-ssl","context":"clientside"}]}`))
+		case "/mgmt/tm/ltm/profile/client-ssl":
+			w.Write([]byte(`{"items":[
+				{"name":"a-ssl","fullPath":"/Common/a-ssl","serverName":"a.example.com","sniDefault":"true","sniRequire":"false"},
+				{"name":"b-ssl","fullPath":"/Common/b-ssl","serverName":"b.example.com","sniDefault":"false","sniRequire":"false"},
+				{"name":"clientssl","fullPath":"/Common/clientssl","sniDefault":"false"}]}`))
+		}
+	}
+
+	err := s.Client.SetVirtualServerSNIDefault("app-vs", "/Common/b-ssl", true)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), []string{
+		"GET /mgmt/tm/ltm/virtual/app-vs/profiles",
+		"GET /mgmt/tm/ltm/profile/client-ssl",
+		"PATCH /mgmt/tm/ltm/profile/client-ssl/~Common~a-ssl",
+		"PATCH /mgmt/tm/ltm/profile/client-ssl/~Common~b-ssl",
+	}, requests)
+	assert.JSONEq(s.T(), `{"sniDefault":"false","sniRequire":"false"}`, bodies[2])
+	assert.JSONEq(s.T(), `{"sniDefault":"true","sniRequire":"true"}`, bodies[3])
+}
+
+func (s *LTMTestSuite) TestSetVirtualServerSNIDefaultNeedsServerName() {
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/mgmt/tm/ltm/virtual/app-vs/profiles":
+			w.Write([]byte(`{"items":[{"name":"a-ssl","fullPath":"/Common/a-ssl"},{"name":"b-ssl","fullPath":"/Common/b-ssl"}]}`))
+		case "/mgmt/tm/ltm/profile/client-ssl":
+			w.Write([]byte(`{"items":[{"name":"a-ssl","fullPath":"/Common/a-ssl","serverName":"none"},{"name":"b-ssl","fullPath":"/Common/b-ssl"}]}`))
+		}
+	}
+
+	err := s.Client.SetVirtualServerSNIDefault("app-vs", "b-ssl", false)
+
+	assert.NotNil(s.T(), err)
+	assert.Equal(s.T(), "GET", s.LastRequest.Method)
+}
+
+func (s *LTMTestSuite) TestAddDNSZone() {
+	config := &DNSZone{
+		Name:                   "example.com",
+		DNSExpressEnabled:      "yes",
+		DNSExpressServer:       "/Common/primary",
+		DNSExpressNotifyAction: "consume",
+		DNSExpressAllowNotify:  []string{"10.1.1.53"},
+		ServerTsigKey:          "/Common/xfer-key",
+	}
+
+	err := s.Client.AddDNSZone(config)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), "POST", s.LastRequest.Method)
+	assert.Equal(s.T(), fmt.Sprintf("/mgmt/tm/%s/%s/%s", uriLtm, uriDns, uriDnsZone), s.LastRequest.URL.Path)
+	assert.JSONEq(s.T(), `{"name":"example.com","dnsExpressEnabled":"yes","dnsExpressServer":"/Common/primary","dnsExpressNotifyAction":"consume","dnsExpressAllowNotify":["10.1.1.53"],"serverTsigKey":"/Common/xfer-key"}`, s.LastRequestBody)
+}
+
+func (s *LTMTestSuite) TestTransferDNSZone() {
+	var requests []string
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path+" "+s.LastRequestBody)
+		if r.Method == "GET" {
+			w.Write([]byte(`{"name":"example.com","partition":"Common","fullPath":"/Common/example.com","dnsExpressEnabled":"yes","dnsExpressServer":"/Common/primary"}`))
+		}
+	}
+
+	err := s.Client.TransferDNSZone("/Common/example.com")
+
+	assert.Nil(s.T(), err)
+	path := fmt.Sprintf("/mgmt/tm/%s/%s/%s/~Common~example.com", uriLtm, uriDns, uriDnsZone)
+	assert.Equal(s.T(), []string{
+		"GET " + path + " ",
+		"PATCH " + path + ` {"dnsExpressEnabled":"no"}`,
+		"PATCH " + path + ` {"dnsExpressEnabled":"yes"}`,
+	}, requests)
+}
+
+func (s *LTMTestSuite) TestTransferDNSZoneWithoutServer() {
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"name":"example.com","fullPath":"/Common/example.com","dnsExpressEnabled":"yes"}`))
+	}
+
+	err := s.Client.TransferDNSZone("/Common/example.com")
+
+	assert.EqualError(s.T(), err, "DNS zone /Common/example.com has no DNS Express server to transfer from")
+	assert.Equal(s.T(), "GET", s.LastRequest.Method)
+}
+
+func (s *LTMTestSuite) TestTransferDNSZoneDisabled() {
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"name":"example.com","fullPath":"/Common/example.com","dnsExpressEnabled":"no","dnsExpressServer":"/Common/primary"}`))
+	}
+
+	err := s.Client.TransferDNSZone("/Common/example.com")
+
+	assert.EqualError(s.T(), err, "DNS Express is not enabled on DNS zone /Common/example.com")
+	assert.Equal(s.T(), "GET", s.LastRequest.Method)
+}
+
+func (s *LTMTestSuite) TestTransferDNSZoneReenableFails() {
+	var requests []string
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+s.LastRequestBody)
+		switch {
+		case r.Method == "GET":
+			w.Write([]byte(`{"name":"example.com","fullPath":"/Common/example.com","dnsExpressEnabled":"yes","dnsExpressServer":"/Common/primary"}`))
+		case strings.Contains(s.LastRequestBody, "yes"):
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"code":503,"message":"service unavailable"}`))
+		}
+	}
+
+	err := s.Client.TransferDNSZone("/Common/example.com")
+
+	assert.EqualError(s.T(), err, "service unavailable (cleanup failed: service unavailable)")
+	assert.Equal(s.T(), []string{
+		"GET ",
+		`PATCH {"dnsExpressEnabled":"no"}`,
+		`PATCH {"dnsExpressEnabled":"yes"}`,
+		`PATCH {"dnsExpressEnabled":"yes"}`,
+	}, requests)
+}
+
+func (s *LTMTestSuite) TestGetDNSZoneStats() {
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"entries":{"https://localhost/mgmt/tm/ltm/dns/zone/~Common~example.com/stats":{"nestedStats":{"entries":{
+			"dnsExpress.soaSerial":{"value":2026101901},
+			"dnsExpress.records":{"value":42},
+			"dnsExpress.notifies":{"value":3},
+			"dnsExpress.axfrQueries":{"value":1},
+			"dnsExpress.ixfrQueries":{"value":2},
+			"tmName":{"description":"/Common/example.com"}}}}}}`))
+	}
+
+	stats, err := s.Client.GetDNSZoneStats("/Common/example.com")
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), fmt.Sprintf("/mgmt/tm/%s/%s/%s/~Common~example.com/stats", uriLtm, uriDns, uriDnsZone), s.LastRequest.URL.Path)
+	assert.Equal(s.T(), int64(2026101901), stats.Serial)
+	assert.Equal(s.T(), int64(42), stats.Records)
+	assert.Equal(s.T(), int64(3), stats.Notifies)
+	assert.Equal(s.T(), int64(1), stats.AXFRQueries)
+	assert.Equal(s.T(), int64(2), stats.IXFRQueries)
+	assert.Equal(s.T(), "/Common/example.com", stats.Counters["tmName"].Description)
+}
+
+func (s *LTMTestSuite) TestDNSTsigKeysAndNameservers() {
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			w.Write([]byte(`{"name":"primary","partition":"Common","fullPath":"/Common/primary","address":"10.1.1.53","port":53,"routeDomain":"/Common/0","tsigKey":"/Common/xfer-key"}`))
+		}
+	}
+
+	err := s.Client.AddDNSTsigKey(&DNSTsigKey{Name: "xfer-key", Algorithm: "hmac-sha256", Secret: "c2VjcmV0"})
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), fmt.Sprintf("/mgmt/tm/%s/%s/%s", uriLtm, uriDns, uriTsigKey), s.LastRequest.URL.Path)
+	assert.JSONEq(s.T(), `{"name":"xfer-key","algorithm":"hmac-sha256","secret":"c2VjcmV0"}`, s.LastRequestBody)
+
+	ns, err := s.Client.GetDNSNameserver("/Common/primary")
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), fmt.Sprintf("/mgmt/tm/%s/%s/%s/~Common~primary", uriLtm, uriDns, uriNameserver), s.LastRequest.URL.Path)
+	assert.Equal(s.T(), "10.1.1.53", ns.Address)
+	assert.Equal(s.T(), 53, ns.Port)
+	assert.Equal(s.T(), "/Common/xfer-key", ns.TsigKey)
+
+	err = s.Client.DeleteDNSTsigKey("/Common/xfer-key")
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), "DELETE", s.LastRequest.Method)
+	assert.Equal(s.T(), fmt.Sprintf("/mgmt/tm/%s/%s/%s/~Common~xfer-key", uriLtm, uriDns, uriTsigKey), s.LastRequest.URL.Path)
+}
+
+func (s *LTMTestSuite) TestAddDNSSECKey() {
+	config := &DNSSECKey{
+		Name:             "example.com-zsk",
+		Algorithm:        "rsasha256",
+		BitWidth:         1024,
+		KeyType:          "zsk",
+		KeyManagement:    "automatic",
+		ExpirationPeriod: 7776000,
+		RolloverPeriod:   6912000,
+	}
+
+	err := s.Client.AddDNSSECKey(config)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), "POST", s.LastRequest.Method)
+	assert.Equal(s.T(), fmt.Sprintf("/mgmt/tm/%s/%s/%s/%s", uriLtm, uriDns, uriDnssec, uriDnssecKey), s.LastRequest.URL.Path)
+	assert.JSONEq(s.T(), `{"name":"example.com-zsk","algorithm":"rsasha256","bitWidth":1024,"keyType":"zsk","keyManagement":"automatic","expirationPeriod":7776000,"rolloverPeriod":6912000}`, s.LastRequestBody)
+}
+
 func (s *LTMTestSuite) TestCreateMonitor() {
 	config := &Monitor{
 		Name:          "test-web-monitor",
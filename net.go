@@ -1,6 +1,8 @@
 package bigip
 
 import (
+	"encoding/json"
+	"fmt"
 	"strings"
 )
 
@@ -46,17 +48,86 @@ type SelfIPs struct {
 // SelfIP contains information about each individual self IP. You can use all of
 // these fields when modifying a self IP.
 type SelfIP struct {
-	Name                  string `json:"name,omitempty"`
-	Partition             string `json:"partition,omitempty"`
-	FullPath              string `json:"fullPath,omitempty"`
-	Generation            int    `json:"generation,omitempty"`
-	Address               string `json:"address,omitempty"`
-	Floating              string `json:"floating,omitempty"`
-	InheritedTrafficGroup string `json:"inheritedTrafficGroup,omitempty"`
-	TrafficGroup          string `json:"trafficGroup,omitempty"`
-	Unit                  int    `json:"unit,omitempty"`
-	Vlan                  string `json:"vlan,omitempty"`
-	// AllowService          []string `json:"allowService"`
+	Name                  string       `json:"name,omitempty"`
+	Partition             string       `json:"partition,omitempty"`
+	FullPath              string       `json:"fullPath,omitempty"`
+	Generation            int          `json:"generation,omitempty"`
+	Address               string       `json:"address,omitempty"`
+	Floating              string       `json:"floating,omitempty"`
+	InheritedTrafficGroup string       `json:"inheritedTrafficGroup,omitempty"`
+	TrafficGroup          string       `json:"trafficGroup,omitempty"`
+	Unit                  int          `json:"unit,omitempty"`
+	Vlan                  string       `json:"vlan,omitempty"`
+	AllowService          AllowService `json:"allowService,omitempty"`
+}
+
+// Port lockdown settings of a self IP. Any other value is a list of
+// "protocol:port" services, optionally including AllowServiceDefault.
+const (
+	AllowServiceAll     = "all"
+	AllowServiceNone    = "none"
+	AllowServiceDefault = "default"
+)
+
+// AllowService contains the port lockdown setting of a self IP. The BIG-IP
+// returns "all" and "none" as plain strings and every other setting as a list,
+// i.e.: ["default", "tcp:8443"]. An empty AllowService is left unchanged when
+// modifying a self IP; use AllowService{AllowServiceNone} to block all services.
+type AllowService []string
+
+// All reports whether every service is allowed.
+func (a AllowService) All() bool {
+	return len(a) == 1 && a[0] == AllowServiceAll
+}
+
+// None reports whether no services are allowed. Self IPs with no lockdown
+// services are returned without the allowService field.
+func (a AllowService) None() bool {
+	return len(a) == 0 || (len(a) == 1 && a[0] == AllowServiceNone)
+}
+
+// Contains reports whether the given service is explicitly listed.
+func (a AllowService) Contains(service string) bool {
+	for _, s := range a {
+		if s == service {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalJSON encodes AllowService as the BIG-IP expects it: "all" and "none" as
+// plain strings and any other setting as a list.
+func (a AllowService) MarshalJSON() ([]byte, error) {
+	if a.All() || (len(a) == 1 && a[0] == AllowServiceNone) {
+		return json.Marshal(a[0])
+	}
+	return json.Marshal([]string(a))
+}
+
+// UnmarshalJSON decodes AllowService from either a plain string or a list. A JSON
+// null or empty string decodes to an empty AllowService.
+func (a *AllowService) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		*a = nil
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		if s == "" {
+			*a = nil
+		} else {
+			*a = AllowService{s}
+		}
+		return nil
+	}
+
+	var l []string
+	if err := json.Unmarshal(b, &l); err != nil {
+		return err
+	}
+	*a = AllowService(l)
+	return nil
 }
 
 // Trunks contains a list of every trunk on the BIG-IP system.
@@ -99,7 +170,9 @@ type Vlan struct {
 	Generation      int    `json:"generation,omitempty"`
 	AutoLastHop     string `json:"autoLastHop,omitempty"`
 	CMPHash         string `json:"cmpHash,omitempty"`
+	CustomerTag     int    `json:"customerTag,omitempty"`
 	DAGRoundRobin   string `json:"dagRoundRobin,omitempty"`
+	Description     string `json:"description,omitempty"`
 	Failsafe        string `json:"failsafe,omitempty"`
 	FailsafeAction  string `json:"failsafeAction,omitempty"`
 	FailsafeTimeout int    `json:"failsafeTimeout,omitempty"`
@@ -112,15 +185,25 @@ type Vlan struct {
 		SamplingRate       int    `json:"samplingRate,omitempty"`
 		SamplingRateGlobal string `json:"samplingRateGlobal,omitempty"`
 	} `json:"sflow,omitempty"`
-	SourceChecking string `json:"sourceChecking,omitempty"`
-	Tag            int    `json:"tag,omitempty"`
+	SourceChecking string          `json:"sourceChecking,omitempty"`
+	Tag            int             `json:"tag,omitempty"`
+	Interfaces     []VlanInterface `json:"interfaces,omitempty"`
+}
+
+// VlanInterfaces contains a list of the interfaces of a VLAN.
+type VlanInterfaces struct {
+	VlanInterfaces []VlanInterface `json:"items"`
 }
 
-// VlanInterface contains fields to be used when adding an interface to a VLAN.
+// VlanInterface contains fields to be used when adding an interface to a VLAN. Name is
+// an interface, e.g. "1.1", or a trunk. TagMode is used for QinQ tagging and is one of
+// "service", "customer", "double" or "none".
 type VlanInterface struct {
 	Name     string `json:"name,omitempty"`
+	FullPath string `json:"fullPath,omitempty"`
 	Tagged   bool   `json:"tagged,omitempty"`
 	Untagged bool   `json:"untagged,omitempty"`
+	TagMode  string `json:"tagMode,omitempty"`
 }
 
 // Routes contains a list of every route on the BIG-IP system.
@@ -191,6 +274,117 @@ type BGPNeighbor struct {
 	RemoteAS   int    `json:"remoteAs,omitempty"`
 }
 
+// Tunnels contains a list of every tunnel on the BIG-IP system.
+type Tunnels struct {
+	Tunnels []Tunnel `json:"items"`
+}
+
+// Tunnel contains information about each individual tunnel. Profile is a tunnel
+// profile, i.e.: "/Common/vxlan" or "/Common/gre". You can use all of these fields
+// when modifying a tunnel.
+type Tunnel struct {
+	Name             string `json:"name,omitempty"`
+	Partition        string `json:"partition,omitempty"`
+	FullPath         string `json:"fullPath,omitempty"`
+	Generation       int    `json:"generation,omitempty"`
+	AutoLasthop      string `json:"autoLasthop,omitempty"`
+	Description      string `json:"description,omitempty"`
+	IdleTimeout      int    `json:"idleTimeout,omitempty"`
+	Key              int    `json:"key,omitempty"`
+	LocalAddress     string `json:"localAddress,omitempty"`
+	Mode             string `json:"mode,omitempty"`
+	MTU              int    `json:"mtu,omitempty"`
+	Profile          string `json:"profile,omitempty"`
+	RemoteAddress    string `json:"remoteAddress,omitempty"`
+	SecondaryAddress string `json:"secondaryAddress,omitempty"`
+	TOS              string `json:"tos,omitempty"`
+	TrafficGroup     string `json:"trafficGroup,omitempty"`
+	Transparent      string `json:"transparent,omitempty"`
+	UsePMTU          string `json:"usePmtu,omitempty"`
+}
+
+// VXLANProfiles contains a list of every VXLAN tunnel profile on the BIG-IP system.
+type VXLANProfiles struct {
+	VXLANProfiles []VXLANProfile `json:"items"`
+}
+
+// VXLANProfile contains information about each individual VXLAN tunnel profile.
+// FloodingType is one of "none", "multicast", "multipoint" or "replicator".
+type VXLANProfile struct {
+	Name              string `json:"name,omitempty"`
+	Partition         string `json:"partition,omitempty"`
+	FullPath          string `json:"fullPath,omitempty"`
+	Generation        int    `json:"generation,omitempty"`
+	DefaultsFrom      string `json:"defaultsFrom,omitempty"`
+	Description       string `json:"description,omitempty"`
+	EncapsulationType string `json:"encapsulationType,omitempty"`
+	FloodingType      string `json:"floodingType,omitempty"`
+	Port              int    `json:"port,omitempty"`
+}
+
+// GREProfiles contains a list of every GRE tunnel profile on the BIG-IP system.
+type GREProfiles struct {
+	GREProfiles []GREProfile `json:"items"`
+}
+
+// GREProfile contains information about each individual GRE tunnel profile.
+// Encapsulation is either "standard" or "nvgre".
+type GREProfile struct {
+	Name          string `json:"name,omitempty"`
+	Partition     string `json:"partition,omitempty"`
+	FullPath      string `json:"fullPath,omitempty"`
+	Generation    int    `json:"generation,omitempty"`
+	DefaultsFrom  string `json:"defaultsFrom,omitempty"`
+	Description   string `json:"description,omitempty"`
+	Encapsulation string `json:"encapsulation,omitempty"`
+	FloodingType  string `json:"floodingType,omitempty"`
+}
+
+// IPIPProfiles contains a list of every IPIP tunnel profile on the BIG-IP system.
+type IPIPProfiles struct {
+	IPIPProfiles []IPIPProfile `json:"items"`
+}
+
+// IPIPProfile contains information about each individual IPIP tunnel profile.
+type IPIPProfile struct {
+	Name         string `json:"name,omitempty"`
+	Partition    string `json:"partition,omitempty"`
+	FullPath     string `json:"fullPath,omitempty"`
+	Generation   int    `json:"generation,omitempty"`
+	DefaultsFrom string `json:"defaultsFrom,omitempty"`
+	Description  string `json:"description,omitempty"`
+}
+
+// FDBTunnels contains a list of the forwarding database of every tunnel on the
+// BIG-IP system.
+type FDBTunnels struct {
+	FDBTunnels []FDBTunnel `json:"items"`
+}
+
+// FDBTunnel contains the forwarding database of a tunnel. The BIG-IP creates one for
+// each tunnel; it cannot be added or deleted on its own.
+type FDBTunnel struct {
+	Name       string      `json:"name,omitempty"`
+	Partition  string      `json:"partition,omitempty"`
+	FullPath   string      `json:"fullPath,omitempty"`
+	Generation int         `json:"generation,omitempty"`
+	Records    []FDBRecord `json:"records,omitempty"`
+}
+
+// fdbRecordsDTO replaces every record of a forwarding database. Records has no
+// `omitempty` so that replacing with no records clears the database, as with data
+// groups (see issue https://github.com/scottdware/go-bigip/issues/90).
+type fdbRecordsDTO struct {
+	Generation int         `json:"generation,omitempty"`
+	Records    []FDBRecord `json:"records"`
+}
+
+// FDBRecord maps a MAC address (Name) to the remote tunnel endpoint it is reached through.
+type FDBRecord struct {
+	Name     string `json:"name"`
+	Endpoint string `json:"endpoint,omitempty"`
+}
+
 const (
 	uriNet         = "net"
 	uriInterface   = "interface"
@@ -202,6 +396,12 @@ const (
 	uriRouting     = "routing"
 	uriBGP         = "bgp"
 	uriNeighbor    = "neighbor"
+	uriTunnels     = "tunnels"
+	uriTunnel      = "tunnel"
+	uriVxlan       = "vxlan"
+	uriGre         = "gre"
+	uriIpip        = "ipip"
+	uriFdb         = "fdb"
 )
 
 // Interfaces returns a list of interfaces.
@@ -264,6 +464,141 @@ func (b *BigIP) ModifySelfIP(name string, config *SelfIP) error {
 	return b.put(config, uriNet, uriSelf, name)
 }
 
+// GetSelfIP returns a named self IP.
+func (b *BigIP) GetSelfIP(name string) (*SelfIP, error) {
+	var self SelfIP
+	err, ok := b.getForEntity(&self, uriNet, uriSelf, name)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	return &self, nil
+}
+
+// SetSelfIPAllowService replaces the port lockdown setting of a self IP, i.e.:
+// AllowService{AllowServiceAll} or AllowService{"default", "tcp:8443"}.
+func (b *BigIP) SetSelfIPAllowService(name string, services AllowService) error {
+	if len(services) == 0 {
+		services = AllowService{AllowServiceNone}
+	}
+	for _, service := range services {
+		if err := checkAllowService(service, len(services)); err != nil {
+			return err
+		}
+	}
+
+	return b.patch(&SelfIP{AllowService: services}, uriNet, uriSelf, name)
+}
+
+// AddSelfIPAllowService allows a single service on a self IP. <service> is
+// either AllowServiceDefault or in "protocol:port" form, i.e.: "tcp:443".
+func (b *BigIP) AddSelfIPAllowService(name, service string) error {
+	if service == AllowServiceAll || service == AllowServiceNone {
+		return fmt.Errorf("%s is not a single service, use SetSelfIPAllowService to allow %s services", service, service)
+	}
+	if err := checkAllowService(service, 2); err != nil {
+		return err
+	}
+
+	self, err := b.GetSelfIP(name)
+	if err != nil {
+		return err
+	}
+	if self == nil {
+		return fmt.Errorf("self IP %s does not exist", name)
+	}
+
+	current := self.AllowService
+	switch {
+	case current.All():
+		return fmt.Errorf("self IP %s already allows all services", name)
+	case current.None():
+		current = nil
+	case current.Contains(service):
+		return fmt.Errorf("%s is already set", service)
+	}
+
+	return b.SetSelfIPAllowService(name, append(current, service))
+}
+
+// RemoveSelfIPAllowService stops allowing a single service on a self IP. When
+// the last service is removed the self IP no longer allows any services.
+func (b *BigIP) RemoveSelfIPAllowService(name, service string) error {
+	self, err := b.GetSelfIP(name)
+	if err != nil {
+		return err
+	}
+	if self == nil {
+		return fmt.Errorf("self IP %s does not exist", name)
+	}
+
+	current := self.AllowService
+	if current.All() {
+		return fmt.Errorf("self IP %s allows all services, set an explicit list instead", name)
+	}
+	if current.None() || !current.Contains(service) {
+		return fmt.Errorf("%s is not set", service)
+	}
+
+	var services AllowService
+	for _, s := range current {
+		if s != service {
+			services = append(services, s)
+		}
+	}
+
+	return b.SetSelfIPAllowService(name, services)
+}
+
+// PermissiveSelfIPs returns the self IPs whose port lockdown allows all
+// services, the default services, or every port of a protocol ("tcp:0").
+func (b *BigIP) PermissiveSelfIPs() ([]SelfIP, error) {
+	selfIPs, err := b.SelfIPs()
+	if err != nil {
+		return nil, err
+	}
+
+	var permissive []SelfIP
+	for _, self := range selfIPs.SelfIPs {
+		if self.AllowService.All() || self.AllowService.Contains(AllowServiceDefault) {
+			permissive = append(permissive, self)
+			continue
+		}
+		for _, service := range self.AllowService {
+			if strings.HasSuffix(service, ":0") || strings.HasSuffix(service, ":any") {
+				permissive = append(permissive, self)
+				break
+			}
+		}
+	}
+
+	return permissive, nil
+}
+
+// checkAllowService validates a single port lockdown entry of a list with
+// <count> entries; "all" and "none" cannot be combined with other services.
+func checkAllowService(service string, count int) error {
+	switch service {
+	case AllowServiceAll, AllowServiceNone:
+		if count > 1 {
+			return fmt.Errorf("%s cannot be combined with other services", service)
+		}
+		return nil
+	case AllowServiceDefault:
+		return nil
+	}
+
+	parts := strings.Split(service, ":")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return fmt.Errorf("invalid service %q, expected protocol:port", service)
+	}
+
+	return nil
+}
+
 // Trunks returns a list of trunks.
 func (b *BigIP) Trunks() (*Trunks, error) {
 	var trunks Trunks
@@ -341,6 +676,62 @@ func (b *BigIP) ModifyVlan(name string, config *Vlan) error {
 	return b.put(config, uriNet, uriVlan, name)
 }
 
+// AddVlan adds a new VLAN by config to the BIG-IP system, including its interfaces.
+func (b *BigIP) AddVlan(config *Vlan) error {
+	return b.post(config, uriNet, uriVlan)
+}
+
+// GetVlan gets a VLAN by name. Returns nil if the VLAN does not exist.
+func (b *BigIP) GetVlan(name string) (*Vlan, error) {
+	var vlan Vlan
+	err, ok := b.getForEntity(&vlan, uriNet, uriVlan, name)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	return &vlan, nil
+}
+
+// PatchVlan updates only the attributes of a VLAN that are set in config, e.g. its
+// failsafe settings, MTU, customer tag or CMP hash.
+func (b *BigIP) PatchVlan(name string, config *Vlan) error {
+	return b.patch(config, uriNet, uriVlan, name)
+}
+
+// VlanInterfaces returns a list of the interfaces and trunks of a VLAN.
+func (b *BigIP) VlanInterfaces(vlan string) (*VlanInterfaces, error) {
+	var interfaces VlanInterfaces
+	err, _ := b.getForEntity(&interfaces, uriNet, uriVlan, vlan, "interfaces")
+	if err != nil {
+		return nil, err
+	}
+
+	return &interfaces, nil
+}
+
+// AddVlanInterface adds an interface or trunk to a VLAN. Set either Tagged or Untagged.
+func (b *BigIP) AddVlanInterface(vlan string, config *VlanInterface) error {
+	return b.post(config, uriNet, uriVlan, vlan, "interfaces")
+}
+
+// ModifyVlanInterface updates an interface or trunk of a VLAN, e.g. its tag mode.
+func (b *BigIP) ModifyVlanInterface(vlan, iface string, config *VlanInterface) error {
+	return b.patch(config, uriNet, uriVlan, vlan, "interfaces", iface)
+}
+
+// SetVlanInterfaceTagged changes whether an interface or trunk of a VLAN is tagged.
+func (b *BigIP) SetVlanInterfaceTagged(vlan, iface string, tagged bool) error {
+	return b.ModifyVlanInterface(vlan, iface, &VlanInterface{Tagged: tagged, Untagged: !tagged})
+}
+
+// RemoveVlanInterface removes an interface or trunk from a VLAN.
+func (b *BigIP) RemoveVlanInterface(vlan, iface string) error {
+	return b.delete(uriNet, uriVlan, vlan, "interfaces", iface)
+}
+
 // Routes returns a list of routes.
 func (b *BigIP) Routes() (*Routes, error) {
 	var routes Routes
@@ -542,3 +933,268 @@ func (b *BigIP) DeleteBGPNeighbor(instance, name string) error {
 func (b *BigIP) ModifyBGPNeighbor(instance, name string, config *BGPNeighbor) error {
 	return b.put(config, uriNet, uriRouting, uriBGP, instance, uriNeighbor, name)
 }
+
+// Tunnels returns a list of tunnels.
+func (b *BigIP) Tunnels() (*Tunnels, error) {
+	var tunnels Tunnels
+	err, _ := b.getForEntity(&tunnels, uriNet, uriTunnels, uriTunnel)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tunnels, nil
+}
+
+// GetTunnel gets a tunnel.
+func (b *BigIP) GetTunnel(name string) (*Tunnel, error) {
+	var tunnel Tunnel
+	err, ok := b.getForEntity(&tunnel, uriNet, uriTunnels, uriTunnel, name)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	return &tunnel, nil
+}
+
+// AddTunnel adds a new tunnel to the BIG-IP system.
+func (b *BigIP) AddTunnel(config *Tunnel) error {
+	return b.post(config, uriNet, uriTunnels, uriTunnel)
+}
+
+// DeleteTunnel removes a tunnel.
+func (b *BigIP) DeleteTunnel(name string) error {
+	return b.delete(uriNet, uriTunnels, uriTunnel, name)
+}
+
+// ModifyTunnel allows you to change any attribute of a tunnel. Fields that
+// can be modified are referenced in the Tunnel struct.
+func (b *BigIP) ModifyTunnel(name string, config *Tunnel) error {
+	return b.put(config, uriNet, uriTunnels, uriTunnel, name)
+}
+
+// VXLANProfiles returns a list of VXLAN tunnel profiles.
+func (b *BigIP) VXLANProfiles() (*VXLANProfiles, error) {
+	var profiles VXLANProfiles
+	err, _ := b.getForEntity(&profiles, uriNet, uriTunnels, uriVxlan)
+	if err != nil {
+		return nil, err
+	}
+
+	return &profiles, nil
+}
+
+// GetVXLANProfile gets a VXLAN tunnel profile.
+func (b *BigIP) GetVXLANProfile(name string) (*VXLANProfile, error) {
+	var profile VXLANProfile
+	err, ok := b.getForEntity(&profile, uriNet, uriTunnels, uriVxlan, name)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	return &profile, nil
+}
+
+// AddVXLANProfile adds a new VXLAN tunnel profile to the BIG-IP system.
+func (b *BigIP) AddVXLANProfile(config *VXLANProfile) error {
+	return b.post(config, uriNet, uriTunnels, uriVxlan)
+}
+
+// DeleteVXLANProfile removes a VXLAN tunnel profile.
+func (b *BigIP) DeleteVXLANProfile(name string) error {
+	return b.delete(uriNet, uriTunnels, uriVxlan, name)
+}
+
+// ModifyVXLANProfile allows you to change any attribute of a VXLAN tunnel profile.
+// Fields that can be modified are referenced in the VXLANProfile struct.
+func (b *BigIP) ModifyVXLANProfile(name string, config *VXLANProfile) error {
+	return b.put(config, uriNet, uriTunnels, uriVxlan, name)
+}
+
+// GREProfiles returns a list of GRE tunnel profiles.
+func (b *BigIP) GREProfiles() (*GREProfiles, error) {
+	var profiles GREProfiles
+	err, _ := b.getForEntity(&profiles, uriNet, uriTunnels, uriGre)
+	if err != nil {
+		return nil, err
+	}
+
+	return &profiles, nil
+}
+
+// GetGREProfile gets a GRE tunnel profile.
+func (b *BigIP) GetGREProfile(name string) (*GREProfile, error) {
+	var profile GREProfile
+	err, ok := b.getForEntity(&profile, uriNet, uriTunnels, uriGre, name)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	return &profile, nil
+}
+
+// AddGREProfile adds a new GRE tunnel profile to the BIG-IP system.
+func (b *BigIP) AddGREProfile(config *GREProfile) error {
+	return b.post(config, uriNet, uriTunnels, uriGre)
+}
+
+// DeleteGREProfile removes a GRE tunnel profile.
+func (b *BigIP) DeleteGREProfile(name string) error {
+	return b.delete(uriNet, uriTunnels, uriGre, name)
+}
+
+// ModifyGREProfile allows you to change any attribute of a GRE tunnel profile.
+// Fields that can be modified are referenced in the GREProfile struct.
+func (b *BigIP) ModifyGREProfile(name string, config *GREProfile) error {
+	return b.put(config, uriNet, uriTunnels, uriGre, name)
+}
+
+// IPIPProfiles returns a list of IPIP tunnel profiles.
+func (b *BigIP) IPIPProfiles() (*IPIPProfiles, error) {
+	var profiles IPIPProfiles
+	err, _ := b.getForEntity(&profiles, uriNet, uriTunnels, uriIpip)
+	if err != nil {
+		return nil, err
+	}
+
+	return &profiles, nil
+}
+
+// GetIPIPProfile gets an IPIP tunnel profile.
+func (b *BigIP) GetIPIPProfile(name string) (*IPIPProfile, error) {
+	var profile IPIPProfile
+	err, ok := b.getForEntity(&profile, uriNet, uriTunnels, uriIpip, name)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	return &profile, nil
+}
+
+// AddIPIPProfile adds a new IPIP tunnel profile to the BIG-IP system.
+func (b *BigIP) AddIPIPProfile(config *IPIPProfile) error {
+	return b.post(config, uriNet, uriTunnels, uriIpip)
+}
+
+// DeleteIPIPProfile removes an IPIP tunnel profile.
+func (b *BigIP) DeleteIPIPProfile(name string) error {
+	return b.delete(uriNet, uriTunnels, uriIpip, name)
+}
+
+// ModifyIPIPProfile allows you to change any attribute of an IPIP tunnel profile.
+// Fields that can be modified are referenced in the IPIPProfile struct.
+func (b *BigIP) ModifyIPIPProfile(name string, config *IPIPProfile) error {
+	return b.put(config, uriNet, uriTunnels, uriIpip, name)
+}
+
+// FDBTunnels returns the forwarding database of every tunnel.
+func (b *BigIP) FDBTunnels() (*FDBTunnels, error) {
+	var tunnels FDBTunnels
+	err, _ := b.getForEntity(&tunnels, uriNet, uriFdb, uriTunnel)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tunnels, nil
+}
+
+// GetFDBTunnel gets the forwarding database of a tunnel.
+func (b *BigIP) GetFDBTunnel(tunnel string) (*FDBTunnel, error) {
+	var fdb FDBTunnel
+	err, ok := b.getForEntity(&fdb, uriNet, uriFdb, uriTunnel, tunnel)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	return &fdb, nil
+}
+
+// AddFDBRecords adds MAC address to endpoint mappings to the forwarding database of a
+// tunnel. Records that already exist are pointed at the given endpoint.
+func (b *BigIP) AddFDBRecords(tunnel string, records []FDBRecord) error {
+	return b.editRecords(b.fdbRecords(tunnel), func(current map[string]string) (add, modify []tmshRecord, remove []string) {
+		for _, r := range records {
+			mac := strings.ToLower(r.Name)
+			endpoint, ok := current[mac]
+			switch {
+			case !ok:
+				add = append(add, tmshRecord{Name: mac, Value: r.Endpoint})
+			case endpoint != r.Endpoint:
+				modify = append(modify, tmshRecord{Name: mac, Value: r.Endpoint})
+			}
+			current[mac] = r.Endpoint
+		}
+		return add, modify, nil
+	})
+}
+
+// RemoveFDBRecords removes MAC addresses from the forwarding database of a tunnel.
+// MAC addresses that are not in the forwarding database are ignored.
+func (b *BigIP) RemoveFDBRecords(tunnel string, macs []string) error {
+	return b.editRecords(b.fdbRecords(tunnel), func(current map[string]string) (add, modify []tmshRecord, remove []string) {
+		for _, mac := range macs {
+			mac = strings.ToLower(mac)
+			if _, ok := current[mac]; ok {
+				remove = append(remove, mac)
+				delete(current, mac)
+			}
+		}
+		return nil, nil, remove
+	})
+}
+
+// fdbRecords describes the forwarding database of a tunnel for editRecords. MAC addresses
+// are compared in lower case.
+func (b *BigIP) fdbRecords(tunnel string) *recordCollection {
+	return &recordCollection{
+		what: "forwarding database of tunnel " + tunnel,
+		read: func() (int, []tmshRecord, error) {
+			fdb, err := b.GetFDBTunnel(tunnel)
+			if err != nil {
+				return 0, nil, err
+			}
+			if fdb == nil {
+				return 0, nil, fmt.Errorf("tunnel %s does not exist", tunnel)
+			}
+			records := make([]tmshRecord, len(fdb.Records))
+			for i, r := range fdb.Records {
+				records[i] = tmshRecord{Name: strings.ToLower(r.Name), Value: r.Endpoint}
+			}
+			return fdb.Generation, records, nil
+		},
+		format: func(r tmshRecord) string {
+			if r.Value == "" {
+				return r.Name + " { }"
+			}
+			return r.Name + " { endpoint " + r.Value + " }"
+		},
+		quote: func(name string) string { return name },
+		patch: func(option string) error {
+			return b.patch(struct{}{}, uriNet, uriFdb, uriTunnel, tunnel, option)
+		},
+		replace: func(generation int, records []tmshRecord) error {
+			config := &fdbRecordsDTO{
+				Generation: generation,
+				Records:    make([]FDBRecord, len(records)),
+			}
+			for i, r := range records {
+				config.Records[i] = FDBRecord{Name: r.Name, Endpoint: r.Value}
+			}
+			return b.put(config, uriNet, uriFdb, uriTunnel, tunnel)
+		},
+	}
+}
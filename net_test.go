@@ -5,6 +5,8 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -161,6 +163,121 @@ func (s *NetTestSuite) TestModifySelfIP() {
 	assertRestCall(s, "PUT", "/mgmt/tm/net/self/0.0.0.0", "")
 }
 
+func (s *NetTestSuite) TestSelfIPAllowServiceJSON() {
+	var ip SelfIP
+
+	assert.Nil(s.T(), json.Unmarshal([]byte(`{"name":"a","allowService":"all"}`), &ip))
+	assert.True(s.T(), ip.AllowService.All())
+
+	assert.Nil(s.T(), json.Unmarshal([]byte(`{"name":"b","allowService":["default","tcp:8443"]}`), &ip))
+	assert.Equal(s.T(), AllowService{"default", "tcp:8443"}, ip.AllowService)
+	assert.False(s.T(), ip.AllowService.All())
+
+	ip = SelfIP{}
+	assert.Nil(s.T(), json.Unmarshal([]byte(`{"name":"c"}`), &ip))
+	assert.True(s.T(), ip.AllowService.None())
+
+	assert.Nil(s.T(), json.Unmarshal([]byte(`{"name":"c","allowService":["tcp:22"]}`), &ip))
+	assert.Nil(s.T(), json.Unmarshal([]byte(`{"name":"c","allowService":null}`), &ip))
+	assert.Nil(s.T(), ip.AllowService)
+	assert.True(s.T(), ip.AllowService.None())
+
+	b, _ := json.Marshal(&SelfIP{AllowService: AllowService{AllowServiceNone}})
+	assert.JSONEq(s.T(), `{"allowService":"none"}`, string(b))
+	b, _ = json.Marshal(&SelfIP{AllowService: AllowService{AllowServiceDefault}})
+	assert.JSONEq(s.T(), `{"allowService":["default"]}`, string(b))
+	b, _ = json.Marshal(&SelfIP{Name: "d"})
+	assert.JSONEq(s.T(), `{"name":"d"}`, string(b))
+}
+
+func (s *NetTestSuite) TestSetSelfIPAllowService() {
+	err := s.Client.SetSelfIPAllowService("/Common/self1", nil)
+
+	assert.Nil(s.T(), err)
+	assertRestCall(s, "PATCH", "/mgmt/tm/net/self/~Common~self1", `{"allowService":"none"}`)
+
+	err = s.Client.SetSelfIPAllowService("/Common/self1", AllowService{"all", "tcp:22"})
+
+	assert.NotNil(s.T(), err)
+
+	err = s.Client.SetSelfIPAllowService("/Common/self1", AllowService{"tcp"})
+
+	assert.NotNil(s.T(), err)
+}
+
+func (s *NetTestSuite) TestAddSelfIPAllowService() {
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			w.Write([]byte(`{"name":"self1","fullPath":"/Common/self1","allowService":["default"]}`))
+		}
+	}
+
+	err := s.Client.AddSelfIPAllowService("/Common/self1", "tcp:8443")
+
+	assert.Nil(s.T(), err)
+	assertRestCall(s, "PATCH", "/mgmt/tm/net/self/~Common~self1", `{"allowService":["default","tcp:8443"]}`)
+
+	err = s.Client.AddSelfIPAllowService("/Common/self1", "default")
+
+	assert.EqualError(s.T(), err, "default is already set")
+
+	s.LastRequest = nil
+	err = s.Client.AddSelfIPAllowService("/Common/self1", "all")
+
+	assert.EqualError(s.T(), err, "all is not a single service, use SetSelfIPAllowService to allow all services")
+	assert.Nil(s.T(), s.LastRequest)
+}
+
+func (s *NetTestSuite) TestAddSelfIPAllowServiceToNone() {
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			w.Write([]byte(`{"name":"self1","fullPath":"/Common/self1"}`))
+		}
+	}
+
+	err := s.Client.AddSelfIPAllowService("self1", "udp:53")
+
+	assert.Nil(s.T(), err)
+	assertRestCall(s, "PATCH", "/mgmt/tm/net/self/self1", `{"allowService":["udp:53"]}`)
+}
+
+func (s *NetTestSuite) TestRemoveSelfIPAllowService() {
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			w.Write([]byte(`{"name":"self1","fullPath":"/Common/self1","allowService":["tcp:22"]}`))
+		}
+	}
+
+	err := s.Client.RemoveSelfIPAllowService("self1", "tcp:22")
+
+	assert.Nil(s.T(), err)
+	assertRestCall(s, "PATCH", "/mgmt/tm/net/self/self1", `{"allowService":"none"}`)
+
+	err = s.Client.RemoveSelfIPAllowService("self1", "tcp:443")
+
+	assert.EqualError(s.T(), err, "tcp:443 is not set")
+}
+
+func (s *NetTestSuite) TestPermissiveSelfIPs() {
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"items":[
+			{"name":"all","allowService":"all"},
+			{"name":"none"},
+			{"name":"default","allowService":["default"]},
+			{"name":"ssh","allowService":["tcp:22"]},
+			{"name":"anytcp","allowService":["tcp:0"]}]}`))
+	}
+
+	ips, err := s.Client.PermissiveSelfIPs()
+
+	assert.Nil(s.T(), err)
+	var names []string
+	for _, ip := range ips {
+		names = append(names, ip.Name)
+	}
+	assert.Equal(s.T(), []string{"all", "default", "anytcp"}, names)
+}
+
 func (s *NetTestSuite) TestTrunks() {
 	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte(`{
@@ -273,6 +390,79 @@ func (s *NetTestSuite) TestModifyVLan() {
 	assertRestCall(s, "PUT", "/mgmt/tm/net/vlan/name", `{"mtu":1500, "sflow":{}}`)
 }
 
+func (s *NetTestSuite) TestAddVlan() {
+	vlan := &Vlan{
+		Name:        "qinq",
+		Tag:         100,
+		CustomerTag: 200,
+		Failsafe:    "enabled",
+		Interfaces:  []VlanInterface{{Name: "1.1", Tagged: true, TagMode: "double"}, {Name: "trunk1", Untagged: true}},
+	}
+
+	err := s.Client.AddVlan(vlan)
+
+	assert.Nil(s.T(), err)
+	assertRestCall(s, "POST", "/mgmt/tm/net/vlan", `{"name":"qinq", "tag":100, "customerTag":200, "failsafe":"enabled", "sflow":{},
+		"interfaces":[{"name":"1.1", "tagged":true, "tagMode":"double"}, {"name":"trunk1", "untagged":true}]}`)
+}
+
+func (s *NetTestSuite) TestPatchVlan() {
+	err := s.Client.PatchVlan("/Common/external", &Vlan{Failsafe: "enabled", FailsafeAction: "failover", FailsafeTimeout: 90, CMPHash: "src-ip"})
+
+	assert.Nil(s.T(), err)
+	assertRestCall(s, "PATCH", "/mgmt/tm/net/vlan/~Common~external", `{"cmpHash":"src-ip", "failsafe":"enabled", "failsafeAction":"failover", "failsafeTimeout":90, "sflow":{}}`)
+}
+
+func (s *NetTestSuite) TestVlanInterfaces() {
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"kind":"tm:net:vlan:interfaces:interfacescollectionstate","items":[
+			{"name":"1.1","fullPath":"1.1","tagged":true,"tagMode":"none"},
+			{"name":"trunk1","fullPath":"trunk1","untagged":true,"tagMode":"none"}]}`))
+	}
+
+	interfaces, err := s.Client.VlanInterfaces("/Common/external")
+
+	assert.Nil(s.T(), err)
+	assertRestCall(s, "GET", "/mgmt/tm/net/vlan/~Common~external/interfaces", "")
+	assert.Equal(s.T(), []VlanInterface{
+		{Name: "1.1", FullPath: "1.1", Tagged: true, TagMode: "none"},
+		{Name: "trunk1", FullPath: "trunk1", Untagged: true, TagMode: "none"},
+	}, interfaces.VlanInterfaces)
+}
+
+func (s *NetTestSuite) TestAddVlanInterface() {
+	err := s.Client.AddVlanInterface("external", &VlanInterface{Name: "trunk1", Tagged: true})
+
+	assert.Nil(s.T(), err)
+	assertRestCall(s, "POST", "/mgmt/tm/net/vlan/external/interfaces", `{"name":"trunk1", "tagged":true}`)
+}
+
+func (s *NetTestSuite) TestSetVlanInterfaceTagged() {
+	err := s.Client.SetVlanInterfaceTagged("external", "1.1", false)
+
+	assert.Nil(s.T(), err)
+	assertRestCall(s, "PATCH", "/mgmt/tm/net/vlan/external/interfaces/1.1", `{"untagged":true}`)
+
+	err = s.Client.SetVlanInterfaceTagged("external", "1.1", true)
+
+	assert.Nil(s.T(), err)
+	assertRestCall(s, "PATCH", "/mgmt/tm/net/vlan/external/interfaces/1.1", `{"tagged":true}`)
+}
+
+func (s *NetTestSuite) TestModifyVlanInterfaceTagMode() {
+	err := s.Client.ModifyVlanInterface("qinq", "1.1", &VlanInterface{TagMode: "service"})
+
+	assert.Nil(s.T(), err)
+	assertRestCall(s, "PATCH", "/mgmt/tm/net/vlan/qinq/interfaces/1.1", `{"tagMode":"service"}`)
+}
+
+func (s *NetTestSuite) TestRemoveVlanInterface() {
+	err := s.Client.RemoveVlanInterface("external", "1.1")
+
+	assert.Nil(s.T(), err)
+	assertRestCall(s, "DELETE", "/mgmt/tm/net/vlan/external/interfaces/1.1", "")
+}
+
 func (s *NetTestSuite) TestRoutes() {
 	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte(`{
@@ -604,6 +794,289 @@ func (s *NetTestSuite) TestModifyBGPNeighbor() {
 	assertRestCall(s, "PUT", "/mgmt/tm/net/routing/bgp/~Common~test/neighbor/1.1.1.1", `{"name":"1.1.1.1", "remoteAs":65001}`)
 }
 
+func (s *NetTestSuite) TestTunnels() {
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"kind":"tm:net:tunnels:tunnel:tunnelcollectionstate","items":[
+			{"name":"http-tunnel","fullPath":"/Common/http-tunnel","profile":"/Common/tcp-forward"},
+			{"name":"overlay","fullPath":"/Common/overlay","profile":"/Common/vxlan-mp","localAddress":"10.1.1.1","remoteAddress":"any","key":100,"mtu":1450}]}`))
+	}
+
+	tunnels, err := s.Client.Tunnels()
+
+	assert.Nil(s.T(), err)
+	assertRestCall(s, "GET", "/mgmt/tm/net/tunnels/tunnel", "")
+	assert.Equal(s.T(), 2, len(tunnels.Tunnels))
+	assert.Equal(s.T(), Tunnel{Name: "overlay", FullPath: "/Common/overlay", Profile: "/Common/vxlan-mp", LocalAddress: "10.1.1.1", RemoteAddress: "any", Key: 100, MTU: 1450}, tunnels.Tunnels[1])
+}
+
+func (s *NetTestSuite) TestAddTunnel() {
+	err := s.Client.AddTunnel(&Tunnel{Name: "overlay", Profile: "/Common/vxlan-mp", LocalAddress: "10.1.1.1", RemoteAddress: "any", Key: 100})
+
+	assert.Nil(s.T(), err)
+	assertRestCall(s, "POST", "/mgmt/tm/net/tunnels/tunnel", `{"name":"overlay", "profile":"/Common/vxlan-mp", "localAddress":"10.1.1.1", "remoteAddress":"any", "key":100}`)
+}
+
+func (s *NetTestSuite) TestModifyTunnel() {
+	err := s.Client.ModifyTunnel("/Common/overlay", &Tunnel{MTU: 1400})
+
+	assert.Nil(s.T(), err)
+	assertRestCall(s, "PUT", "/mgmt/tm/net/tunnels/tunnel/~Common~overlay", `{"mtu":1400}`)
+}
+
+func (s *NetTestSuite) TestDeleteTunnel() {
+	err := s.Client.DeleteTunnel("/Common/overlay")
+
+	assert.Nil(s.T(), err)
+	assertRestCall(s, "DELETE", "/mgmt/tm/net/tunnels/tunnel/~Common~overlay", "")
+}
+
+func (s *NetTestSuite) TestGetTunnelNotFound() {
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"code":404,"message":"01020036:3: The requested tunnel (/Common/missing) was not found.","errorStack":[]}`))
+	}
+
+	tunnel, err := s.Client.GetTunnel("/Common/missing")
+
+	assert.Nil(s.T(), err)
+	assert.Nil(s.T(), tunnel)
+}
+
+func (s *NetTestSuite) TestAddVXLANProfile() {
+	err := s.Client.AddVXLANProfile(&VXLANProfile{Name: "vxlan-mp", DefaultsFrom: "/Common/vxlan", FloodingType: "multipoint", Port: 4789})
+
+	assert.Nil(s.T(), err)
+	assertRestCall(s, "POST", "/mgmt/tm/net/tunnels/vxlan", `{"name":"vxlan-mp", "defaultsFrom":"/Common/vxlan", "floodingType":"multipoint", "port":4789}`)
+}
+
+func (s *NetTestSuite) TestGetVXLANProfile() {
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"kind":"tm:net:tunnels:vxlan:vxlanstate","name":"vxlan-mp","partition":"Common","fullPath":"/Common/vxlan-mp","generation":3,
+			"defaultsFrom":"/Common/vxlan","encapsulationType":"vxlan","floodingType":"multipoint","port":4789}`))
+	}
+
+	profile, err := s.Client.GetVXLANProfile("/Common/vxlan-mp")
+
+	assert.Nil(s.T(), err)
+	assertRestCall(s, "GET", "/mgmt/tm/net/tunnels/vxlan/~Common~vxlan-mp", "")
+	assert.Equal(s.T(), &VXLANProfile{Name: "vxlan-mp", Partition: "Common", FullPath: "/Common/vxlan-mp", Generation: 3,
+		DefaultsFrom: "/Common/vxlan", EncapsulationType: "vxlan", FloodingType: "multipoint", Port: 4789}, profile)
+}
+
+func (s *NetTestSuite) TestModifyGREProfile() {
+	err := s.Client.ModifyGREProfile("nvgre", &GREProfile{Encapsulation: "nvgre", FloodingType: "multipoint"})
+
+	assert.Nil(s.T(), err)
+	assertRestCall(s, "PUT", "/mgmt/tm/net/tunnels/gre/nvgre", `{"encapsulation":"nvgre", "floodingType":"multipoint"}`)
+}
+
+func (s *NetTestSuite) TestIPIPProfiles() {
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"items":[{"name":"ipip","fullPath":"/Common/ipip"}]}`))
+	}
+
+	profiles, err := s.Client.IPIPProfiles()
+
+	assert.Nil(s.T(), err)
+	assertRestCall(s, "GET", "/mgmt/tm/net/tunnels/ipip", "")
+	assert.Equal(s.T(), []IPIPProfile{{Name: "ipip", FullPath: "/Common/ipip"}}, profiles.IPIPProfiles)
+}
+
+func (s *NetTestSuite) TestDeleteIPIPProfile() {
+	err := s.Client.DeleteIPIPProfile("/Common/ipip-custom")
+
+	assert.Nil(s.T(), err)
+	assertRestCall(s, "DELETE", "/mgmt/tm/net/tunnels/ipip/~Common~ipip-custom", "")
+}
+
+const fdbTunnelResponse = `{"kind":"tm:net:fdb:tunnel:tunnelstate","name":"overlay","partition":"Common","fullPath":"/Common/overlay","generation":12,
+	"records":[{"name":"00:11:22:33:44:55","endpoint":"10.1.1.2"},{"name":"00:11:22:33:44:66","endpoint":"10.1.1.3"}]}`
+
+func (s *NetTestSuite) TestGetFDBTunnel() {
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(fdbTunnelResponse))
+	}
+
+	fdb, err := s.Client.GetFDBTunnel("/Common/overlay")
+
+	assert.Nil(s.T(), err)
+	assertRestCall(s, "GET", "/mgmt/tm/net/fdb/tunnel/~Common~overlay", "")
+	assert.Equal(s.T(), []FDBRecord{
+		{Name: "00:11:22:33:44:55", Endpoint: "10.1.1.2"},
+		{Name: "00:11:22:33:44:66", Endpoint: "10.1.1.3"},
+	}, fdb.Records)
+}
+
+func (s *NetTestSuite) TestAddFDBRecords() {
+	var requests []string
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Query().Get("options"))
+		if r.Method == "GET" {
+			w.Write([]byte(fdbTunnelResponse))
+		}
+	}
+
+	err := s.Client.AddFDBRecords("/Common/overlay", []FDBRecord{
+		{Name: "00:11:22:33:44:55", Endpoint: "10.1.1.2"},
+		{Name: "00:11:22:33:44:66", Endpoint: "10.1.1.9"},
+		{Name: "00:11:22:33:44:AA", Endpoint: "10.1.1.4"},
+	})
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), []string{
+		"GET ",
+		"PATCH records add { 00:11:22:33:44:aa { endpoint 10.1.1.4 } }",
+		"PATCH records modify { 00:11:22:33:44:66 { endpoint 10.1.1.9 } }",
+	}, requests)
+	assertRestCall(s, "PATCH", "/mgmt/tm/net/fdb/tunnel/~Common~overlay", `{}`)
+}
+
+func (s *NetTestSuite) TestAddFDBRecordsNoChange() {
+	var requests []string
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method)
+		w.Write([]byte(fdbTunnelResponse))
+	}
+
+	err := s.Client.AddFDBRecords("overlay", []FDBRecord{{Name: "00:11:22:33:44:55", Endpoint: "10.1.1.2"}})
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), []string{"GET"}, requests)
+}
+
+func (s *NetTestSuite) TestRemoveFDBRecords() {
+	var requests []string
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Query().Get("options"))
+		if r.Method == "GET" {
+			w.Write([]byte(fdbTunnelResponse))
+		}
+	}
+
+	err := s.Client.RemoveFDBRecords("overlay", []string{"00:11:22:33:44:55", "00:11:22:33:44:66", "00:11:22:33:44:77"})
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), []string{
+		"GET ",
+		"PATCH records delete { 00:11:22:33:44:55 00:11:22:33:44:66 }",
+	}, requests)
+}
+
+func (s *NetTestSuite) TestAddFDBRecordsFallback() {
+	var requests []string
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method)
+		switch r.Method {
+		case "GET":
+			w.Write([]byte(fdbTunnelResponse))
+		case "PATCH":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code":400,"message":"Found invalid option records","errorStack":[]}`))
+		}
+	}
+
+	err := s.Client.AddFDBRecords("overlay", []FDBRecord{
+		{Name: "00:11:22:33:44:77", Endpoint: "10.1.1.4"},
+		{Name: "00:11:22:33:44:55", Endpoint: "10.1.1.5"},
+	})
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), []string{"GET", "PATCH", "GET", "PUT"}, requests)
+	assertRestCall(s, "PUT", "/mgmt/tm/net/fdb/tunnel/overlay", `{"generation":12,"records":[
+		{"name":"00:11:22:33:44:55","endpoint":"10.1.1.5"},
+		{"name":"00:11:22:33:44:66","endpoint":"10.1.1.3"},
+		{"name":"00:11:22:33:44:77","endpoint":"10.1.1.4"}]}`)
+}
+
+func (s *NetTestSuite) TestRemoveFDBRecordsFallbackAll() {
+	var requests []string
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method)
+		switch r.Method {
+		case "GET":
+			w.Write([]byte(fdbTunnelResponse))
+		case "PATCH":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code":400,"message":"Found invalid option records","errorStack":[]}`))
+		}
+	}
+
+	err := s.Client.RemoveFDBRecords("overlay", []string{"00:11:22:33:44:55", "00:11:22:33:44:66"})
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), []string{"GET", "PATCH", "GET", "PUT"}, requests)
+	assertRestCall(s, "PUT", "/mgmt/tm/net/fdb/tunnel/overlay", `{"generation":12,"records":[]}`)
+}
+
+func (s *NetTestSuite) TestRemoveFDBRecordsFallbackGenerationChanged() {
+	// Another client adds 00:11:22:33:44:77 after the records were first read.
+	changed := strings.Replace(fdbTunnelResponse, `"generation":12`, `"generation":13`, 1)
+	changed = strings.Replace(changed, `"endpoint":"10.1.1.3"}`, `"endpoint":"10.1.1.3"},{"name":"00:11:22:33:44:77","endpoint":"10.1.1.4"}`, 1)
+	var requests []string
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method)
+		switch r.Method {
+		case "GET":
+			if len(requests) == 1 {
+				w.Write([]byte(fdbTunnelResponse))
+			} else {
+				w.Write([]byte(changed))
+			}
+		case "PATCH":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code":400,"message":"Found invalid option records","errorStack":[]}`))
+		}
+	}
+
+	err := s.Client.RemoveFDBRecords("overlay", []string{"00:11:22:33:44:55"})
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), []string{"GET", "PATCH", "GET", "GET", "PUT"}, requests)
+	assertRestCall(s, "PUT", "/mgmt/tm/net/fdb/tunnel/overlay", `{"generation":13,"records":[
+		{"name":"00:11:22:33:44:66","endpoint":"10.1.1.3"},
+		{"name":"00:11:22:33:44:77","endpoint":"10.1.1.4"}]}`)
+}
+
+func (s *NetTestSuite) TestRemoveFDBRecordsFallbackKeepsChanging() {
+	generation := 12
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			generation++
+			w.Write([]byte(`{"name":"overlay","generation":` + strconv.Itoa(generation) + `,"records":[{"name":"00:11:22:33:44:55","endpoint":"10.1.1.2"}]}`))
+		case "PATCH":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code":400,"message":"Found invalid option records","errorStack":[]}`))
+		case "PUT":
+			s.T().Error("records were replaced although the forwarding database kept changing")
+		}
+	}
+
+	err := s.Client.RemoveFDBRecords("overlay", []string{"00:11:22:33:44:55"})
+
+	assert.EqualError(s.T(), err, "forwarding database of tunnel overlay kept changing, gave up after 3 attempts")
+}
+
+func (s *NetTestSuite) TestAddFDBRecordsNoFallbackOnOtherErrors() {
+	var requests []string
+	s.ResponseFunc = func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method)
+		switch r.Method {
+		case "GET":
+			w.Write([]byte(fdbTunnelResponse))
+		case "PATCH":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"code":401,"message":"Authorization failed","errorStack":[]}`))
+		}
+	}
+
+	err := s.Client.AddFDBRecords("overlay", []FDBRecord{{Name: "00:11:22:33:44:77", Endpoint: "10.1.1.4"}})
+
+	assert.EqualError(s.T(), err, "Authorization failed")
+	assert.Equal(s.T(), []string{"GET", "PATCH"}, requests)
+}
+
 func assertRestCall(s *NetTestSuite, method, path, body string) {
 	assert.Equal(s.T(), method, s.LastRequest.Method)
 	assert.Equal(s.T(), path, s.LastRequest.URL.Path)
@@ -11,27 +11,46 @@ import (
 const (
 	uriClientSSL       = "client-ssl"
 	uriDatagroup       = "data-group"
+	uriDns             = "dns"
+	uriDnsZone         = "zone"
+	uriDnssec          = "dnssec"
+	uriDnssecKey       = "key"
 	uriHttp            = "http"
 	uriHttpCompression = "http-compression"
+	uriExternal        = "external"
 	uriIRule           = "rule"
 	uriInternal        = "internal"
 	uriLtm             = "ltm"
 	uriMonitor         = "monitor"
 	uriNode            = "node"
 	uriOneConnect      = "one-connect"
+	uriPersistence     = "persistence"
+	uriPersistRecords  = "persist-records"
 	uriPolicy          = "policy"
 	uriPool            = "pool"
 	uriPoolMember      = "members"
 	uriProfile         = "profile"
 	uriRules           = "rules"
 	uriServerSSL       = "server-ssl"
+	uriNameserver      = "nameserver"
+	uriNat             = "nat"
+	uriSnat            = "snat"
 	uriSnatPool        = "snatpool"
+	uriSnatTranslation = "snat-translation"
 	uriTcp             = "tcp"
+	uriTsigKey         = "tsig-key"
 	uriUdp             = "udp"
 	uriVirtual         = "virtual"
 	uriVirtualAddress  = "virtual-address"
 	uriGtm             = "gtm"
 	uriWideIp          = "wideip"
+	uriDatacenter      = "datacenter"
+	uriServer          = "server"
+	uriVirtualServers  = "virtual-servers"
+	uriTopology        = "topology"
+	uriRegion          = "region"
+	uriProberPool      = "prober-pool"
+	uriListener        = "listener"
 	uriARecord         = "a"
 	uriAAAARecord      = "aaaa"
 	uriCNameRecord     = "cname"
@@ -45,8 +64,10 @@ const (
 	CONTEXT_CLIENT     = "clientside"
 	CONTEXT_ALL        = "all"
 
-	// Newer policy APIs have a draft-publish workflow that this library does not support.
+	// Policies on BIG-IP versions before 12.1 are managed through the 11.5.1 API, which
+	// has no draft-publish workflow.
 	policyVersionSuffix = "?ver=11.5.1"
+	policyDraftsSubPath = "Drafts"
 )
 
 var cidr = map[string]string{
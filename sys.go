@@ -1,8 +1,14 @@
 package bigip
 
 import (
+	"bytes"
 	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 )
 
 const (
@@ -18,7 +24,12 @@ const (
 	uriSslCert        = "ssl-cert"
 	uriSslKey         = "ssl-key"
 	//uriPlatform = "?$select=platform"
-	uriConfig = "config"
+	uriConfig          = "config"
+	uriConnection      = "connection"
+	uriExternalMonitor = "external-monitor"
+	uriVersion         = "version"
+	uriUtil            = "util"
+	uriUnixRm          = "unix-rm"
 )
 
 type Volumes struct {
@@ -362,9 +373,596 @@ func (b *BigIP) DeleteKey(name string) error {
 	return b.delete(uriSys, uriFile, uriSslKey, name)
 }
 
+// DataGroupFiles contains a list of every data group file on the BIG-IP system.
+type DataGroupFiles struct {
+	DataGroupFiles []DataGroupFile `json:"items,omitempty"`
+}
+
+// DataGroupFile represents an imported data group file, the contents of an external
+// data group.
+type DataGroupFile struct {
+	Name           string `json:"name,omitempty"`
+	Partition      string `json:"partition,omitempty"`
+	FullPath       string `json:"fullPath,omitempty"`
+	Generation     int    `json:"generation,omitempty"`
+	Checksum       string `json:"checksum,omitempty"`
+	CreatedBy      string `json:"createdBy,omitempty"`
+	CreateTime     string `json:"createTime,omitempty"`
+	LastUpdateTime string `json:"lastUpdateTime,omitempty"`
+	Mode           int    `json:"mode,omitempty"`
+	Revision       int    `json:"revision,omitempty"`
+	Separator      string `json:"separator,omitempty"`
+	Size           uint64 `json:"size,omitempty"`
+	SourcePath     string `json:"sourcePath,omitempty"`
+	Type           string `json:"type,omitempty"`
+	UpdatedBy      string `json:"updatedBy,omitempty"`
+}
+
+// DataGroupFiles returns a list of data group files.
+func (b *BigIP) DataGroupFiles() (*DataGroupFiles, error) {
+	var files DataGroupFiles
+	err, _ := b.getForEntity(&files, uriSys, uriFile, uriDatagroup)
+	if err != nil {
+		return nil, err
+	}
+
+	return &files, nil
+}
+
+// AddDataGroupFile imports a data group file from its SourcePath, i.e. a file uploaded
+// with UploadBytes.
+func (b *BigIP) AddDataGroupFile(config *DataGroupFile) error {
+	return b.post(config, uriSys, uriFile, uriDatagroup)
+}
+
+// GetDataGroupFile retrieves a data group file by name. Returns nil if the file does not exist.
+func (b *BigIP) GetDataGroupFile(name string) (*DataGroupFile, error) {
+	var file DataGroupFile
+	err, ok := b.getForEntity(&file, uriSys, uriFile, uriDatagroup, name)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	return &file, nil
+}
+
+// ModifyDataGroupFile allows you to change any attribute of a data group file. Setting
+// SourcePath re-imports the file, replacing its contents.
+func (b *BigIP) ModifyDataGroupFile(name string, config *DataGroupFile) error {
+	return b.put(config, uriSys, uriFile, uriDatagroup, name)
+}
+
+// DeleteDataGroupFile removes a data group file. The file cannot be removed while an
+// external data group references it.
+func (b *BigIP) DeleteDataGroupFile(name string) error {
+	return b.delete(uriSys, uriFile, uriDatagroup, name)
+}
+
+// ExternalMonitorFiles contains a list of every external monitor script on the BIG-IP system.
+type ExternalMonitorFiles struct {
+	ExternalMonitorFiles []ExternalMonitorFile `json:"items,omitempty"`
+}
+
+// ExternalMonitorFile represents an imported external monitor script, run by an external
+// monitor.
+type ExternalMonitorFile struct {
+	Name           string `json:"name,omitempty"`
+	Partition      string `json:"partition,omitempty"`
+	FullPath       string `json:"fullPath,omitempty"`
+	Generation     int    `json:"generation,omitempty"`
+	Checksum       string `json:"checksum,omitempty"`
+	CreatedBy      string `json:"createdBy,omitempty"`
+	CreateTime     string `json:"createTime,omitempty"`
+	LastUpdateTime string `json:"lastUpdateTime,omitempty"`
+	Mode           int    `json:"mode,omitempty"`
+	Revision       int    `json:"revision,omitempty"`
+	Size           uint64 `json:"size,omitempty"`
+	SourcePath     string `json:"sourcePath,omitempty"`
+	UpdatedBy      string `json:"updatedBy,omitempty"`
+}
+
+// ExternalMonitorFiles returns a list of external monitor scripts.
+func (b *BigIP) ExternalMonitorFiles() (*ExternalMonitorFiles, error) {
+	var files ExternalMonitorFiles
+	err, _ := b.getForEntity(&files, uriSys, uriFile, uriExternalMonitor)
+	if err != nil {
+		return nil, err
+	}
+
+	return &files, nil
+}
+
+// AddExternalMonitorFile imports an external monitor script from its SourcePath, i.e. a
+// file uploaded with UploadBytes.
+func (b *BigIP) AddExternalMonitorFile(config *ExternalMonitorFile) error {
+	return b.post(config, uriSys, uriFile, uriExternalMonitor)
+}
+
+// GetExternalMonitorFile retrieves an external monitor script by name. Returns nil if the
+// script does not exist.
+func (b *BigIP) GetExternalMonitorFile(name string) (*ExternalMonitorFile, error) {
+	var file ExternalMonitorFile
+	err, ok := b.getForEntity(&file, uriSys, uriFile, uriExternalMonitor, name)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	return &file, nil
+}
+
+// ModifyExternalMonitorFile allows you to change any attribute of an external monitor
+// script. Setting SourcePath re-imports the script.
+func (b *BigIP) ModifyExternalMonitorFile(name string, config *ExternalMonitorFile) error {
+	return b.put(config, uriSys, uriFile, uriExternalMonitor, name)
+}
+
+// DeleteExternalMonitorFile removes an external monitor script. The script cannot be
+// removed while an external monitor runs it.
+func (b *BigIP) DeleteExternalMonitorFile(name string) error {
+	return b.delete(uriSys, uriFile, uriExternalMonitor, name)
+}
+
+// TMOSVersion returns the TMOS version of the BIG-IP system, e.g. "13.1.0.8". The version
+// is cached after the first successful call; it is safe to call from multiple goroutines.
+func (b *BigIP) TMOSVersion() (string, error) {
+	if b.version != nil {
+		b.version.Lock()
+		version := b.version.version
+		b.version.Unlock()
+		if version != "" {
+			return version, nil
+		}
+	}
+
+	var stats statsEntries
+	err, _ := b.getForEntity(&stats, uriSys, uriVersion)
+	if err != nil {
+		return "", err
+	}
+	var version string
+	for _, e := range stats.Entries {
+		version = e.NestedStats.Entries["Version"].Description
+	}
+	if version == "" {
+		return "", fmt.Errorf("BIG-IP did not report its version")
+	}
+
+	if b.version != nil {
+		b.version.Lock()
+		b.version.version = version
+		b.version.Unlock()
+	}
+
+	return version, nil
+}
+
+// versionAtLeast reports whether a TMOS version such as "12.1.2" is the given major and
+// minor version or later.
+func versionAtLeast(version string, major, minor int) bool {
+	parts := strings.SplitN(version, ".", 3)
+	if len(parts) < 2 {
+		return false
+	}
+	v1, err1 := strconv.Atoi(parts[0])
+	v2, err2 := strconv.Atoi(parts[1])
+	if err1 != nil || err2 != nil {
+		return false
+	}
+
+	return v1 > major || (v1 == major && v2 >= minor)
+}
+
+// ImportCertificate uploads a PEM encoded certificate and installs it as <name>. The
+// uploaded file is removed afterwards. Returns the installed certificate.
+func (b *BigIP) ImportCertificate(name string, certPEM []byte) (*Certificate, error) {
+	if err := checkPEM(certPEM, "CERTIFICATE"); err != nil {
+		return nil, err
+	}
+
+	return b.importCertificate(name, certPEM)
+}
+
+// ImportCertificateBundle installs the PEM encoded certificates as a single bundle,
+// e.g. a certificate followed by its intermediates, or a CA bundle used as a chain.
+func (b *BigIP) ImportCertificateBundle(name string, certsPEM ...[]byte) (*Certificate, error) {
+	var bundle []byte
+	for _, c := range certsPEM {
+		if err := checkPEM(c, "CERTIFICATE"); err != nil {
+			return nil, err
+		}
+		bundle = append(bundle, bytes.TrimSpace(c)...)
+		bundle = append(bundle, '\n')
+	}
+	if len(bundle) == 0 {
+		return nil, fmt.Errorf("no certificates to import")
+	}
+
+	return b.importCertificate(name, bundle)
+}
+
+func (b *BigIP) importCertificate(name string, data []byte) (*Certificate, error) {
+	sourcePath, cleanup, err := b.uploadForImport(name, data)
+	if err != nil {
+		return nil, err
+	}
+	err = b.AddCertificate(&Certificate{Name: name, SourcePath: sourcePath})
+	if err != nil {
+		return nil, withCleanupErrors(err, cleanup())
+	}
+
+	cert, err := b.GetCertificate(name)
+	if err != nil {
+		return nil, withCleanupErrors(err, cleanup())
+	}
+
+	return cert, cleanup()
+}
+
+// ImportKey uploads a PEM encoded private key and installs it as <name>. Set passphrase
+// for an encrypted key. The uploaded file is removed afterwards. Returns the installed key.
+func (b *BigIP) ImportKey(name string, keyPEM []byte, passphrase string) (*Key, error) {
+	if err := checkPEM(keyPEM, "PRIVATE KEY"); err != nil {
+		return nil, err
+	}
+
+	sourcePath, cleanup, err := b.uploadForImport(name, keyPEM)
+	if err != nil {
+		return nil, err
+	}
+	config := &Key{
+		Name:       name,
+		SourcePath: sourcePath,
+		Passphrase: passphrase,
+	}
+	if passphrase != "" {
+		config.SecurityType = "password"
+	}
+	err = b.AddKey(config)
+	if err != nil {
+		return nil, withCleanupErrors(err, cleanup())
+	}
+
+	key, err := b.GetKey(name)
+	if err != nil {
+		return nil, withCleanupErrors(err, cleanup())
+	}
+
+	return key, cleanup()
+}
+
+// uploadForImport uploads data to import a sys file from, and returns its source path and
+// a function that removes the uploaded file.
+func (b *BigIP) uploadForImport(name string, data []byte) (string, func() error, error) {
+	filename := strings.Replace(strings.TrimPrefix(name, "/"), "/", "_", -1)
+	upload, err := b.UploadBytes(data, filename)
+	if err != nil {
+		return "", nil, err
+	}
+
+	cleanup := func() error {
+		return b.RemoveFile(upload.LocalFilePath)
+	}
+	return "file:" + upload.LocalFilePath, cleanup, nil
+}
+
+// RemoveFile removes a file from the BIG-IP file system, e.g. a file uploaded with
+// UploadBytes once it has been imported.
+func (b *BigIP) RemoveFile(path string) error {
+	config := map[string]string{
+		"command":     "run",
+		"utilCmdArgs": path,
+	}
+	return b.post(config, uriUtil, uriUnixRm)
+}
+
+// checkPEM checks that data holds at least one PEM block whose type ends in blockType,
+// e.g. "PRIVATE KEY" matches both "RSA PRIVATE KEY" and "EC PRIVATE KEY". Other blocks,
+// such as the "EC PARAMETERS" written by openssl ecparam, are allowed alongside it.
+func checkPEM(data []byte, blockType string) error {
+	found := false
+	for rest := data; ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if strings.HasSuffix(block.Type, blockType) {
+			found = true
+		}
+	}
+	if !found {
+		return fmt.Errorf("no PEM encoded %s found", strings.ToLower(blockType))
+	}
+
+	return nil
+}
+
+// CertificateReport describes an installed certificate and the objects that use it.
+type CertificateReport struct {
+	Certificate             Certificate
+	Expires                 time.Time
+	Subject                 map[string]string // e.g. "CN", "O" and "C"
+	SubjectAlternativeNames []string          // e.g. "DNS:www.example.com", "IP Address:10.1.1.1"
+	ClientSSLProfiles       []string
+	ServerSSLProfiles       []string
+	VirtualServers          []string
+}
+
+// ExpiringCertificates reports the certificates that expire within the given window,
+// including those that have already expired, soonest first. Each certificate is mapped to
+// the client-ssl and server-ssl profiles that use it and to the virtual servers those
+// profiles are attached to.
+func (b *BigIP) ExpiringCertificates(within time.Duration) ([]CertificateReport, error) {
+	certs, err := b.Certificates()
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(within)
+	var reports []CertificateReport
+	for _, c := range certs.Certificates {
+		expires := time.Unix(int64(c.ExpirationDate), 0)
+		if expires.After(deadline) {
+			continue
+		}
+		reports = append(reports, CertificateReport{
+			Certificate:             c,
+			Expires:                 expires,
+			Subject:                 parseCertificateSubject(c.Subject),
+			SubjectAlternativeNames: parseSubjectAlternativeNames(c.SubjectAlternativeName),
+		})
+	}
+	if len(reports) == 0 {
+		return reports, nil
+	}
+	sort.SliceStable(reports, func(i, j int) bool {
+		return reports[i].Expires.Before(reports[j].Expires)
+	})
+
+	byPath := map[string]*CertificateReport{}
+	for i := range reports {
+		byPath[reports[i].Certificate.FullPath] = &reports[i]
+	}
+	if err := b.certificateUsage(byPath); err != nil {
+		return nil, err
+	}
+
+	return reports, nil
+}
+
+// certificateUsage fills in the profiles and virtual servers that use each of the
+// certificates in reports, keyed by the certificate full path.
+func (b *BigIP) certificateUsage(reports map[string]*CertificateReport) error {
+	clientSSL, err := b.ClientSSLProfiles()
+	if err != nil {
+		return err
+	}
+	serverSSL, err := b.ServerSSLProfiles()
+	if err != nil {
+		return err
+	}
+
+	users := map[string][]*CertificateReport{}
+	for _, p := range clientSSL.ClientSSLProfiles {
+		refs := []string{p.Cert, p.Chain}
+		for _, c := range p.CertKeyChain {
+			refs = append(refs, c.Cert, c.Chain)
+		}
+		for _, r := range certificateReferences(reports, refs...) {
+			r.ClientSSLProfiles = append(r.ClientSSLProfiles, p.FullPath)
+			users[p.FullPath] = append(users[p.FullPath], r)
+		}
+	}
+	for _, p := range serverSSL.ServerSSLProfiles {
+		for _, r := range certificateReferences(reports, p.Cert, p.Chain) {
+			r.ServerSSLProfiles = append(r.ServerSSLProfiles, p.FullPath)
+			users[p.FullPath] = append(users[p.FullPath], r)
+		}
+	}
+	if len(users) == 0 {
+		return nil
+	}
+
+	var vs virtualServersWithProfiles
+	err, _ = b.getForEntity(&vs, uriLtm, uriVirtual, "?expandSubcollections=true")
+	if err != nil {
+		return err
+	}
+	for _, v := range vs.VirtualServers {
+		seen := map[*CertificateReport]bool{}
+		for _, p := range v.ProfilesReference.Profiles {
+			for _, r := range users[p.FullPath] {
+				if !seen[r] {
+					seen[r] = true
+					r.VirtualServers = append(r.VirtualServers, v.FullPath)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// virtualServersWithProfiles is used only when listing virtual servers with their profiles
+// expanded.
+type virtualServersWithProfiles struct {
+	VirtualServers []struct {
+		FullPath          string `json:"fullPath"`
+		ProfilesReference struct {
+			Profiles []Profile `json:"items"`
+		} `json:"profilesReference"`
+	} `json:"items"`
+}
+
+// certificateReferences returns the reports of the certificates referenced by paths,
+// each at most once.
+func certificateReferences(reports map[string]*CertificateReport, paths ...string) []*CertificateReport {
+	var refs []*CertificateReport
+	seen := map[string]bool{}
+	for _, p := range paths {
+		if r, ok := reports[p]; ok && !seen[p] {
+			seen[p] = true
+			refs = append(refs, r)
+		}
+	}
+
+	return refs
+}
+
+// parseCertificateSubject parses a distinguished name such as "CN=www.example.com,O=Example,C=US".
+func parseCertificateSubject(subject string) map[string]string {
+	fields := map[string]string{}
+	var part strings.Builder
+	escaped := false
+	flush := func() {
+		kv := strings.SplitN(strings.TrimSpace(part.String()), "=", 2)
+		if len(kv) == 2 {
+			fields[kv[0]] = kv[1]
+		}
+		part.Reset()
+	}
+	for _, r := range subject {
+		switch {
+		case escaped:
+			part.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == ',':
+			flush()
+		default:
+			part.WriteRune(r)
+		}
+	}
+	flush()
+
+	return fields
+}
+
+// parseSubjectAlternativeNames splits a subject alternative name list such as
+// "DNS:www.example.com, DNS:example.com, IP Address:10.1.1.1".
+func parseSubjectAlternativeNames(san string) []string {
+	var names []string
+	for _, n := range strings.Split(san, ",") {
+		if n = strings.TrimSpace(n); n != "" {
+			names = append(names, n)
+		}
+	}
+
+	return names
+}
+
+// RotateCertificate installs a new certificate and key as <name>.crt and <name>.key and
+// switches every client-ssl and server-ssl profile that uses the certificate <current>
+// over to them. The profiles are updated in a single transaction, so either all of them
+// are switched or none are. Set passphrase for an encrypted key. The old certificate and
+// key are left installed. Returns the full paths of the updated profiles.
+func (b *BigIP) RotateCertificate(current, name string, certPEM, keyPEM []byte, passphrase string) ([]string, error) {
+	if err := checkPEM(certPEM, "CERTIFICATE"); err != nil {
+		return nil, err
+	}
+	if err := checkPEM(keyPEM, "PRIVATE KEY"); err != nil {
+		return nil, err
+	}
+	old, err := b.GetCertificate(current)
+	if err != nil {
+		return nil, err
+	}
+	if old == nil {
+		return nil, fmt.Errorf("certificate %s does not exist", current)
+	}
+	clientSSL, err := b.ClientSSLProfiles()
+	if err != nil {
+		return nil, err
+	}
+	serverSSL, err := b.ServerSSLProfiles()
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := b.ImportKey(name+".key", keyPEM, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := b.ImportCertificate(name+".crt", certPEM)
+	if err != nil {
+		return nil, withCleanupErrors(err, b.DeleteKey(name+".key"))
+	}
+
+	profiles, err := b.switchCertificate(old.FullPath, cert.FullPath, key.FullPath, passphrase, clientSSL, serverSSL)
+	if err != nil {
+		return nil, withCleanupErrors(err, b.DeleteCertificate(name+".crt"), b.DeleteKey(name+".key"))
+	}
+
+	return profiles, nil
+}
+
+// switchCertificate points every profile that uses the certificate old at cert and key,
+// in a single transaction.
+func (b *BigIP) switchCertificate(old, cert, key, passphrase string, clientSSL *ClientSSLProfiles, serverSSL *ServerSSLProfiles) ([]string, error) {
+	var profiles []string
+	t, err := b.StartTransaction()
+	if err != nil {
+		return nil, err
+	}
+	abort := func(err error) ([]string, error) {
+		return nil, withCleanupErrors(err, b.AbortTransaction(t))
+	}
+	tx := b.InTransaction(t)
+
+	for _, p := range clientSSL.ClientSSLProfiles {
+		var config *ClientSSLProfile
+		if len(p.CertKeyChain) > 0 {
+			chain := make([]CertKeyChain, len(p.CertKeyChain))
+			switched := false
+			for i, c := range p.CertKeyChain {
+				if c.Cert == old {
+					c.Cert, c.Key, c.Passphrase = cert, key, passphrase
+					switched = true
+				}
+				chain[i] = c
+			}
+			if switched {
+				config = &ClientSSLProfile{CertKeyChain: chain}
+			}
+		} else if p.Cert == old {
+			config = &ClientSSLProfile{Cert: cert, Key: key, Passphrase: passphrase}
+		}
+		if config == nil {
+			continue
+		}
+		if err := tx.ModifyClientSSLProfile(p.FullPath, config); err != nil {
+			return abort(err)
+		}
+		profiles = append(profiles, p.FullPath)
+	}
+	for _, p := range serverSSL.ServerSSLProfiles {
+		if p.Cert != old {
+			continue
+		}
+		config := &ServerSSLProfile{Cert: cert, Key: key, Passphrase: passphrase}
+		if err := tx.ModifyServerSSLProfile(p.FullPath, config); err != nil {
+			return abort(err)
+		}
+		profiles = append(profiles, p.FullPath)
+	}
+
+	if err := b.CommitTransaction(t); err != nil {
+		return nil, err
+	}
+
+	return profiles, nil
+}
+
 type SysConfig struct {
 	Command string                   `json:"command"`
-	Options []map[string]interface{} `json:"options, omitempty"`
+	Options []map[string]interface{} `json:"options,omitempty"`
 }
 
 //SaveSysConfig saves the running configuration to file. The file can be either an .scf file or a .tar file
@@ -403,3 +1001,113 @@ func buildSysConfigOptions(fileName string, passphrase string) []map[string]inte
 	}
 	return options
 }
+
+// ConnectionFilter selects entries of the connection table. The client side server is the
+// virtual server and the server side server is the pool member. Empty fields are ignored.
+type ConnectionFilter struct {
+	ClientAddress           string
+	ClientPort              string
+	VirtualAddress          string
+	VirtualPort             string
+	ServerSideClientAddress string
+	ServerSideClientPort    string
+	MemberAddress           string
+	MemberPort              string
+	Protocol                string
+}
+
+// Connection contains information about each entry in the connection table, as reported
+// by "show sys connection".
+type Connection struct {
+	ClientSideClient string
+	ClientSideServer string
+	ServerSideClient string
+	ServerSideServer string
+	Protocol         string
+	IdleTime         int
+	TMM              int
+}
+
+func (f *ConnectionFilter) options() []string {
+	return []string{
+		"cs-client-addr", f.ClientAddress,
+		"cs-client-port", f.ClientPort,
+		"cs-server-addr", f.VirtualAddress,
+		"cs-server-port", f.VirtualPort,
+		"ss-client-addr", f.ServerSideClientAddress,
+		"ss-client-port", f.ServerSideClientPort,
+		"ss-server-addr", f.MemberAddress,
+		"ss-server-port", f.MemberPort,
+		"protocol", f.Protocol,
+	}
+}
+
+// Connections returns the entries of the connection table matching the filter. A nil
+// filter returns every connection.
+func (b *BigIP) Connections(filter *ConnectionFilter) ([]Connection, error) {
+	if filter == nil {
+		filter = &ConnectionFilter{}
+	}
+	out, err := b.rawRequest("get", filter.options(), uriSys, uriConnection)
+	if err != nil {
+		return nil, err
+	}
+
+	connections := []Connection{}
+	for _, fields := range parseTmshRecords(out) {
+		if len(fields) < 5 {
+			continue
+		}
+		c := Connection{
+			ClientSideClient: fields[0],
+			ClientSideServer: fields[1],
+			ServerSideClient: fields[2],
+			ServerSideServer: fields[3],
+			Protocol:         fields[4],
+			TMM:              parseTmshTMM(fields),
+		}
+		if len(fields) > 5 {
+			c.IdleTime, _ = strconv.Atoi(fields[5])
+		}
+		connections = append(connections, c)
+	}
+
+	return connections, nil
+}
+
+// DeleteConnections removes the entries of the connection table matching the filter. At
+// least one field of the filter must be set.
+func (b *BigIP) DeleteConnections(filter *ConnectionFilter) error {
+	if filter == nil || tmshOptions(filter.options()...) == "" {
+		return fmt.Errorf("a filter is required to delete connections")
+	}
+	_, err := b.rawRequest("delete", filter.options(), uriSys, uriConnection)
+	return err
+}
+
+// parseTmshRecords splits the output of a tmsh show command into the fields of each
+// record, skipping the "Sys::" header and the "Total records returned" footer.
+func parseTmshRecords(out string) [][]string {
+	var records [][]string
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "Sys::") || strings.HasPrefix(line, "Total records returned") {
+			continue
+		}
+		records = append(records, strings.Fields(line))
+	}
+
+	return records
+}
+
+// parseTmshTMM returns the tmm number from the "(tmm: 1)" field of a tmsh record.
+func parseTmshTMM(fields []string) int {
+	for i, f := range fields {
+		if f == "(tmm:" && i+1 < len(fields) {
+			tmm, _ := strconv.Atoi(strings.TrimSuffix(fields[i+1], ")"))
+			return tmm
+		}
+	}
+
+	return 0
+}